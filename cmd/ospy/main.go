@@ -1,17 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/ravikantchauhan246/ospy/internal/config"
+	"github.com/ravikantchauhan246/ospy/internal/jsonpath"
 	"github.com/ravikantchauhan246/ospy/internal/monitor"
 	"github.com/ravikantchauhan246/ospy/internal/notifier"
 	"github.com/ravikantchauhan246/ospy/internal/storage"
@@ -145,22 +150,172 @@ func main() {
 
 	// Create notification manager
 	notifManager := notifier.NewManager(notifiers)
+	notifManager.SetCertExpiryThresholds(cfg.Monitoring.CertExpiryDays)
 
 	// Create checker and worker pool
 	checker := monitor.NewChecker(cfg.Monitoring.Timeout)
 	workerPool := monitor.NewWorkerPool(cfg.Monitoring.Workers, checker)
+	workerPool.SetRetries(cfg.Monitoring.Retries, cfg.Monitoring.RetryBackoff)
 
 	// Convert config websites to monitor websites
 	websites := make([]monitor.Website, len(cfg.Websites))
 	for i, w := range cfg.Websites {
+		var schedule monitor.Schedule
+		if w.Schedule != "" {
+			// Already checked by Validate
+			schedule, _ = config.ParseSchedule(w.Schedule, w.Timezone)
+		}
+		// Compile regexes once; they're already checked by Validate
+		var checkContentRegex, mustNotContainRegex *regexp.Regexp
+		if w.CheckContentRegex != "" {
+			checkContentRegex = regexp.MustCompile(w.CheckContentRegex)
+		}
+		if w.MustNotContainRegex != "" {
+			mustNotContainRegex = regexp.MustCompile(w.MustNotContainRegex)
+		}
+		var answerPattern *regexp.Regexp
+		if w.AnswerPattern != "" {
+			answerPattern = regexp.MustCompile(w.AnswerPattern)
+		}
+		ignoreRegions := make([]*regexp.Regexp, len(w.IgnoreRegions))
+		for j, expr := range w.IgnoreRegions {
+			ignoreRegions[j] = regexp.MustCompile(expr)
+		}
+		expectedHeaders := make([]monitor.HeaderRule, len(w.ExpectedHeaders))
+		for j, h := range w.ExpectedHeaders {
+			expectedHeaders[j] = monitor.HeaderRule{Name: h.Name, Equals: h.Equals, Absent: h.Absent}
+			if h.Matches != "" {
+				expectedHeaders[j].Matches = regexp.MustCompile(h.Matches)
+			}
+		}
+		steps := make([]monitor.Step, len(w.Steps))
+		for j, st := range w.Steps {
+			extract := make([]monitor.Extract, len(st.Extract))
+			for k, e := range st.Extract {
+				extract[k] = monitor.Extract{Name: e.Name, Header: e.Header, Cookie: e.Cookie}
+				if e.JSON != "" {
+					extract[k].JSON = jsonpath.MustParse(e.JSON)
+				}
+			}
+			steps[j] = monitor.Step{
+				Name:           st.Name,
+				Method:         st.Method,
+				URL:            st.URL,
+				Headers:        st.Headers,
+				Body:           st.Body,
+				ContentType:    st.ContentType,
+				ExpectedStatus: st.ExpectedStatus,
+				Assertions:     newAssertions(st.Assertions),
+				Extract:        extract,
+			}
+		}
+		var auth *monitor.Auth
+		if a := w.Auth; a != nil {
+			auth = &monitor.Auth{
+				Type:         a.Type,
+				Username:     a.Username,
+				Password:     a.Password,
+				Token:        a.Token,
+				TokenURL:     a.TokenURL,
+				ClientID:     a.ClientID,
+				ClientSecret: a.ClientSecret,
+				Scopes:       a.Scopes,
+			}
+		}
+
 		websites[i] = monitor.Website{
-			Name:           w.Name,
-			URL:            w.URL,
-			Method:         w.Method,
-			Headers:        w.Headers,
-			ExpectedStatus: w.ExpectedStatus,
-			CheckContent:   w.CheckContent,
-			Timeout:        w.Timeout,
+			Name:            w.Name,
+			Type:            w.Type,
+			URL:             w.URL,
+			Method:          w.Method,
+			Headers:         w.Headers,
+			ExpectedStatus:  w.ExpectedStatus,
+			ExpectedHeaders: expectedHeaders,
+			CheckContent:    w.CheckContent,
+			Assertions:      newAssertions(w.Assertions),
+			Body:            w.Body,
+			BodyFile:        w.BodyFile,
+			ContentType:     w.ContentType,
+			Timeout:         w.Timeout,
+			Interval:        w.Interval,
+			Schedule:        schedule,
+
+			DisableRedirects: w.FollowRedirects != nil && !*w.FollowRedirects,
+			MaxRedirects:     w.MaxRedirects,
+			ExpectedFinalURL: w.ExpectedFinalURL,
+			ExpectedLocation: w.ExpectedLocation,
+
+			WarnResponseTime: w.WarnResponseTime,
+			MaxResponseTime:  w.MaxResponseTime,
+
+			Proxy:              w.Proxy,
+			InsecureSkipVerify: w.InsecureSkipVerify,
+			CAFile:             w.CAFile,
+			ClientCert:         w.ClientCert,
+			ClientKey:          w.ClientKey,
+			Auth:               auth,
+
+			Send:   w.Send,
+			Expect: w.Expect,
+
+			RecordType:      w.RecordType,
+			Resolver:        w.Resolver,
+			ExpectedAnswers: w.ExpectedAnswers,
+			AnswerPattern:   answerPattern,
+
+			Steps: steps,
+
+			GRPCService: w.GRPCService,
+			GRPCTLS:     w.GRPCTLS,
+
+			HostKeyFingerprint: w.HostKeyFingerprint,
+			HostKeyAlgorithm:   w.HostKeyAlgorithm,
+
+			StartTLS: w.StartTLS,
+
+			MustNotContain:      w.MustNotContain,
+			CheckContentRegex:   checkContentRegex,
+			MustNotContainRegex: mustNotContainRegex,
+
+			DetectChanges: w.DetectChanges,
+			IgnoreRegions: ignoreRegions,
+			BaselineFile:  w.BaselineFile,
+
+			DSN:            w.DSN,
+			Query:          w.Query,
+			ExpectedResult: w.ExpectedResult,
+			ExpectedRole:   w.ExpectedRole,
+
+			Command: w.Command,
+
+			PushToken: w.PushToken,
+			Period:    w.Period,
+			Grace:     w.Grace,
+		}
+	}
+
+	// Pick up change detection from the content seen before the last restart
+	for _, website := range websites {
+		if !website.DetectChanges {
+			continue
+		}
+		hash, err := storage.GetLastContentHash(website.Name)
+		if err != nil {
+			log.Printf("Failed to load content hash for %s: %v", website.Name, err)
+			continue
+		}
+		if hash != "" {
+			checker.SetContentHash(website.Name, hash)
+		}
+	}
+
+	// Push websites report in through the web server instead of being polled
+	var polled, pushed []monitor.Website
+	for _, website := range websites {
+		if website.Type == config.TypePush {
+			pushed = append(pushed, website)
+		} else {
+			polled = append(polled, website)
 		}
 	}
 
@@ -168,41 +323,68 @@ func main() {
 	workerPool.Start()
 
 	// Create monitor
-	mon := monitor.NewMonitor(workerPool, websites, cfg.Monitoring.Interval, storage)
+	mon := monitor.NewMonitor(workerPool, polled, cfg.Monitoring.Interval, storage)
+	pushMonitor := monitor.NewPushMonitor(pushed)
+
+	handleResult := func(result monitor.CheckResult) {
+		// Send to monitor for logging
+		mon.GetResults() <- result
+
+		// Send to notification manager
+		notifResult := notifier.CheckResult{
+			WebsiteName:  result.WebsiteName,
+			URL:          result.URL,
+			Status:       result.Status,
+			ResponseTime: result.ResponseTime,
+			Error:        result.Error,
+			Timestamp:    result.Timestamp,
+			IsUp:         result.IsUp,
+			Degraded:     result.Degraded,
+			Message:      result.Message,
+			CertExpiry:   result.CertExpiry,
+
+			SecurityAlert: result.SecurityAlert,
+
+			ContentChanged: result.ContentChanged,
+			ContentDiff:    result.ContentDiff,
+		}
+		notifManager.HandleResult(notifResult)
+	}
 
-	// Connect worker pool results to monitor and notifications
+	// Connect worker pool and push results to monitor and notifications
 	go func() {
 		for result := range workerPool.Results() {
-			// Send to monitor for logging
-			mon.GetResults() <- result
-
-			// Send to notification manager
-			notifResult := notifier.CheckResult{
-				WebsiteName:  result.WebsiteName,
-				URL:          result.URL,
-				Status:       result.Status,
-				ResponseTime: result.ResponseTime,
-				Error:        result.Error,
-				Timestamp:    result.Timestamp,
-				IsUp:         result.IsUp,
-				Message:      result.Message,
-			}
-			notifManager.HandleResult(notifResult)
+			handleResult(result)
+		}
+	}()
+	pushForwarded := make(chan struct{})
+	go func() {
+		defer close(pushForwarded)
+		for result := range pushMonitor.Results() {
+			handleResult(result)
 		}
 	}()
 	// Start monitoring
 	mon.Start()
+	if len(pushed) > 0 {
+		pushMonitor.Start()
+	}
 	// Start web server if enabled
+	var webServer *web.Server
 	if cfg.Web.Enabled {
-		webServer := web.NewServer(storage, cfg.Web.Port)
+		webServer = web.NewServer(storage, cfg.Web.Port)
+		webServer.SetCertExpiryThresholds(cfg.Monitoring.CertExpiryDays)
 		
 		// Setup configuration API
 		configAPI := web.NewConfigAPI(*configPath, cfg)
 		webServer.SetConfigAPI(configAPI)
+		if len(pushed) > 0 {
+			webServer.SetPushMonitor(pushMonitor)
+		}
 		
 		go func() {
 			log.Printf("Starting web dashboard on http://%s:%d", cfg.Web.Host, cfg.Web.Port)
-			if err := webServer.Start(); err != nil {
+			if err := webServer.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 				log.Printf("Web server error: %v", err)
 			}
 		}()
@@ -256,7 +438,34 @@ func main() {
 	<-sigChan
 
 	log.Println("Shutting down...")
+	// Stop everything that sends results before the monitor closes its channel
+	if webServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := webServer.Stop(ctx); err != nil {
+			log.Printf("Web server shutdown error: %v", err)
+		}
+		cancel()
+	}
+	pushMonitor.Stop()
+	<-pushForwarded
 	mon.Stop()
 	workerPool.Close()
 	log.Println("Shutdown complete")
 }
+
+// newAssertions converts assertions from the config, parsing their paths and
+// regexes once. They're already checked by Validate.
+func newAssertions(configs []config.AssertionConfig) []monitor.Assertion {
+	assertions := make([]monitor.Assertion, len(configs))
+	for i, a := range configs {
+		assertions[i] = monitor.Assertion{
+			Path:     jsonpath.MustParse(a.Path),
+			Operator: a.Operator,
+			Value:    a.Value,
+		}
+		if a.Operator == "matches" {
+			assertions[i].Pattern = regexp.MustCompile(a.Value)
+		}
+	}
+	return assertions
+}
@@ -2,9 +2,19 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ravikantchauhan246/ospy/internal/jsonpath"
+	"github.com/ravikantchauhan246/ospy/internal/secret"
+	"github.com/ravikantchauhan246/ospy/internal/statuscode"
+	"github.com/ravikantchauhan246/ospy/internal/stepvar"
+	"github.com/robfig/cron/v3"
 	"gopkg.in/yaml.v3"
 )
 
@@ -24,17 +34,199 @@ type MonitoringConfig struct {
 	Timeout  time.Duration `yaml:"timeout"`
 	Retries  int           `yaml:"retries"`
 	Workers  int           `yaml:"workers"`
+
+	// Delay before the first retry of a failed check, doubled on each retry
+	RetryBackoff time.Duration `yaml:"retry_backoff"`
+
+	// Days before certificate expiry at which to send a warning
+	CertExpiryDays []int `yaml:"cert_expiry_days"`
 }
 
 // WebsiteConfig represents a website to monitor
 type WebsiteConfig struct {
+	Name            string             `yaml:"name"`
+	Type            string             `yaml:"type"` // http (default), tcp, dns, tls, transaction, push, exec, grpc, websocket, postgres, mysql, redis, smtp, imap, pop3 or ssh
+	URL             string             `yaml:"url"`
+	Method          string             `yaml:"method"`
+	Headers         map[string]string  `yaml:"headers"`
+	ExpectedStatus  statuscode.Spec    `yaml:"expected_status,omitempty"` // 200, [200, 204], "2xx" or "200-399"
+	CheckContent    string             `yaml:"check_content"`
+	Timeout         time.Duration      `yaml:"timeout"`
+	Interval        time.Duration      `yaml:"interval,omitempty"`         // Overrides monitoring.interval
+	Schedule        string             `yaml:"schedule,omitempty"`         // Cron expression, replaces the interval when set
+	Timezone        string             `yaml:"timezone,omitempty"`         // Timezone the schedule is evaluated in, local if empty
+	ExpectedHeaders []HeaderRuleConfig `yaml:"expected_headers,omitempty"` // Checks against response headers
+	Assertions      []AssertionConfig  `yaml:"assertions,omitempty"`       // Checks against a JSON response body
+	Body            string             `yaml:"body,omitempty"`             // Request body, ${VAR} is replaced from the environment
+	BodyFile        string             `yaml:"body_file,omitempty"`        // File to read the request body from instead of body
+	ContentType     string             `yaml:"content_type,omitempty"`     // Content-Type header sent with the body
+
+	// Content checks besides check_content; only the first 10 MB of the body is read
+	MustNotContain      string `yaml:"must_not_contain,omitempty"`       // Text that marks an error page, e.g. "Database connection failed"
+	CheckContentRegex   string `yaml:"check_content_regex,omitempty"`    // Regex the body must match
+	MustNotContainRegex string `yaml:"must_not_contain_regex,omitempty"` // Regex the body must not match
+
+	// Content change detection for HTTP checks
+	DetectChanges bool     `yaml:"detect_changes,omitempty"` // Alert when the body differs from the previous check
+	IgnoreRegions []string `yaml:"ignore_regions,omitempty"` // Regexes stripped from the body before comparing
+	BaselineFile  string   `yaml:"baseline_file,omitempty"`  // Pinned content to compare with instead of the previous check
+
+	// Redirect handling for HTTP checks
+	FollowRedirects  *bool  `yaml:"follow_redirects,omitempty"`   // Defaults to true
+	MaxRedirects     int    `yaml:"max_redirects,omitempty"`      // Defaults to 10
+	ExpectedFinalURL string `yaml:"expected_final_url,omitempty"` // URL the redirect chain must end at
+	ExpectedLocation string `yaml:"expected_location,omitempty"`  // Location header expected when redirects are not followed
+
+	WarnResponseTime time.Duration `yaml:"warn_response_time,omitempty"` // Slower responses are DEGRADED
+	MaxResponseTime  time.Duration `yaml:"max_response_time,omitempty"`  // Slower responses are DOWN
+
+	// HTTP client settings; the TLS settings also apply to tls checks
+	Proxy              string `yaml:"proxy,omitempty"`                // http://, https:// or socks5:// proxy URL
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"` // Accept any server certificate
+	CAFile             string `yaml:"ca_file,omitempty"`              // PEM bundle trusted in addition to the system roots
+	ClientCert         string `yaml:"client_cert,omitempty"`          // PEM client certificate for mutual TLS
+	ClientKey          string `yaml:"client_key,omitempty"`           // PEM private key for client_cert
+
+	Auth *AuthConfig `yaml:"auth,omitempty"` // Credentials added to HTTP requests, or basic credentials for mail logins
+
+	// Transaction check settings; url defaults to the first step's URL
+	Steps []StepConfig `yaml:"steps,omitempty"` // Requests run in order, sharing cookies
+
+	// gRPC check settings; url holds host:port and headers are sent as metadata
+	GRPCService string `yaml:"grpc_service,omitempty"` // Service to check, the whole server if empty
+	GRPCTLS     bool   `yaml:"grpc_tls,omitempty"`     // Use TLS with the site's TLS settings instead of plaintext
+
+	// SSH check settings; url holds host[:port], port 22 by default
+	HostKeyFingerprint string `yaml:"host_key_fingerprint,omitempty"` // SHA256:... as printed by ssh-keygen -lf
+	HostKeyAlgorithm   string `yaml:"host_key_algorithm,omitempty"`   // Key type to request, e.g. ssh-ed25519
+
+	// Mail check settings for smtp, imap and pop3; url holds host[:port], and
+	// smtps://, imaps:// or pop3s:// use implicit TLS
+	StartTLS bool `yaml:"starttls,omitempty"` // Upgrade the plaintext connection before logging in
+
+	// Database check settings for postgres, mysql and redis; url is only a label
+	DSN            secret.Ref `yaml:"dsn,omitempty"`             // Connection string, from env or file
+	Query          string     `yaml:"query,omitempty"`           // postgres/mysql: defaults to SELECT 1
+	ExpectedResult string     `yaml:"expected_result,omitempty"` // postgres/mysql: first column of the first row
+	ExpectedRole   string     `yaml:"expected_role,omitempty"`   // redis: master or replica
+
+	// Exec check settings; runs a Nagios-compatible plugin without a shell
+	Command []string `yaml:"command,omitempty"` // Program and arguments
+
+	// Push check settings; the job pings /api/push/{push_token}
+	PushToken string        `yaml:"push_token,omitempty"`
+	Period    time.Duration `yaml:"period,omitempty"` // Expected time between pings
+	Grace     time.Duration `yaml:"grace,omitempty"`  // Extra time allowed before the site is DOWN
+
+	// TCP, WebSocket, mail and SSH check settings; for tcp, url holds host:port
+	Send   string `yaml:"send,omitempty"`   // Payload written after connecting
+	Expect string `yaml:"expect,omitempty"` // Substring expected in the response, or in the mail or SSH banner
+
+	// DNS check settings; url holds the name to resolve
+	RecordType      string   `yaml:"record_type,omitempty"`      // A (default), AAAA, CNAME, MX, TXT or NS
+	Resolver        string   `yaml:"resolver,omitempty"`         // host:port of the DNS server, system resolver if empty
+	ExpectedAnswers []string `yaml:"expected_answers,omitempty"` // Exact set of answers expected
+	AnswerPattern   string   `yaml:"answer_pattern,omitempty"`   // Regex every answer must match
+}
+
+// AssertionConfig checks a value in a JSON response body
+type AssertionConfig struct {
+	Path     string `yaml:"path"`     // JSONPath-like expression, e.g. $.db.healthy
+	Operator string `yaml:"operator"` // equals, not_equals, greater_than, less_than, exists, not_exists or matches
+	Value    string `yaml:"value,omitempty"`
+}
+
+// HeaderRuleConfig checks a response header. With no equals, matches or
+// absent set the header only has to be present.
+type HeaderRuleConfig struct {
+	Name    string `yaml:"name"`
+	Equals  string `yaml:"equals,omitempty"`  // Exact value
+	Matches string `yaml:"matches,omitempty"` // Regex the value must match
+	Absent  bool   `yaml:"absent,omitempty"`  // Header must not be sent
+}
+
+// StepConfig is one request of a transaction check. {{name}} in the URL,
+// headers and body is replaced by a value extracted by an earlier step.
+type StepConfig struct {
 	Name           string            `yaml:"name"`
-	URL            string            `yaml:"url"`
-	Method         string            `yaml:"method"`
-	Headers        map[string]string `yaml:"headers"`
-	ExpectedStatus int               `yaml:"expected_status"`
-	CheckContent   string            `yaml:"check_content"`
-	Timeout        time.Duration     `yaml:"timeout"`
+	Method         string            `yaml:"method,omitempty"` // Defaults to GET
+	URL            string            `yaml:"url"`              // Absolute, or relative to the website URL
+	Headers        map[string]string `yaml:"headers,omitempty"`
+	Body           string            `yaml:"body,omitempty"`
+	ContentType    string            `yaml:"content_type,omitempty"`
+	ExpectedStatus statuscode.Spec   `yaml:"expected_status,omitempty"`
+	Assertions     []AssertionConfig `yaml:"assertions,omitempty"`
+	Extract        []ExtractConfig   `yaml:"extract,omitempty"`
+}
+
+// ExtractConfig saves a value from a step's response for later steps. Exactly
+// one of json, header or cookie is set.
+type ExtractConfig struct {
+	Name   string `yaml:"name"`
+	JSON   string `yaml:"json,omitempty"`   // JSONPath-like expression into the body
+	Header string `yaml:"header,omitempty"` // Response header name
+	Cookie string `yaml:"cookie,omitempty"` // Cookie name
+}
+
+// AuthConfig adds credentials to HTTP checks. Secrets are references to
+// environment variables or files, so their values never end up in the config.
+type AuthConfig struct {
+	Type string `yaml:"type"` // basic, bearer or oauth2
+
+	// basic
+	Username string     `yaml:"username,omitempty"`
+	Password secret.Ref `yaml:"password,omitempty"`
+
+	// bearer
+	Token secret.Ref `yaml:"token,omitempty"`
+
+	// oauth2 client credentials
+	TokenURL     string     `yaml:"token_url,omitempty"`
+	ClientID     string     `yaml:"client_id,omitempty"`
+	ClientSecret secret.Ref `yaml:"client_secret,omitempty"`
+	Scopes       []string   `yaml:"scopes,omitempty"`
+}
+
+// Auth types supported by HTTP checks
+const (
+	AuthBasic  = "basic"
+	AuthBearer = "bearer"
+	AuthOAuth2 = "oauth2"
+)
+
+// Check types supported by the monitor
+const (
+	TypeHTTP        = "http"
+	TypeTCP         = "tcp"
+	TypeDNS         = "dns"
+	TypeTLS         = "tls"
+	TypeTransaction = "transaction"
+	TypePush        = "push"
+	TypeExec        = "exec"
+	TypeGRPC        = "grpc"
+	TypeWebSocket   = "websocket"
+	TypePostgres    = "postgres"
+	TypeMySQL       = "mysql"
+	TypeRedis       = "redis"
+	TypeSMTP        = "smtp"
+	TypeIMAP        = "imap"
+	TypePOP3        = "pop3"
+	TypeSSH         = "ssh"
+)
+
+// pushTokenPattern limits push tokens to characters that are safe in a URL path
+var pushTokenPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// assertionOperators lists the operators an assertion can use, and whether
+// they need a value
+var assertionOperators = map[string]bool{
+	"equals": true, "not_equals": true, "greater_than": true, "less_than": true,
+	"matches": true, "exists": false, "not_exists": false,
+}
+
+// dnsRecordTypes lists the record types a DNS check can query
+var dnsRecordTypes = map[string]bool{
+	"A": true, "AAAA": true, "CNAME": true, "MX": true, "TXT": true, "NS": true,
 }
 
 // NotificationConfig contains notification settings
@@ -90,7 +282,10 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var config Config
+	// Defaults that may be set to 0 are filled in before parsing
+	config := Config{
+		Monitoring: MonitoringConfig{Retries: 3},
+	}
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
@@ -110,8 +305,11 @@ func Load(path string) (*Config, error) {
 	if config.Monitoring.Workers == 0 {
 		config.Monitoring.Workers = 10
 	}
-	if config.Monitoring.Retries == 0 {
-		config.Monitoring.Retries = 3
+	if config.Monitoring.RetryBackoff == 0 {
+		config.Monitoring.RetryBackoff = 2 * time.Second
+	}
+	if len(config.Monitoring.CertExpiryDays) == 0 {
+		config.Monitoring.CertExpiryDays = []int{30, 14, 7, 1}
 	}
 	if config.Storage.Path == "" {
 		config.Storage.Path = "data/ospy.db"
@@ -127,12 +325,33 @@ func Load(path string) (*Config, error) {
 
 	// Set website defaults
 	for i := range config.Websites {
+		if config.Websites[i].Type == "" {
+			config.Websites[i].Type = TypeHTTP
+		}
+		if config.Websites[i].Type == TypeTransaction && config.Websites[i].URL == "" && len(config.Websites[i].Steps) > 0 {
+			config.Websites[i].URL = config.Websites[i].Steps[0].URL
+		}
+		if config.Websites[i].Type == TypePush && config.Websites[i].URL == "" && config.Websites[i].PushToken != "" {
+			config.Websites[i].URL = "/api/push/" + config.Websites[i].PushToken
+		}
+		if config.Websites[i].Type == TypeExec && config.Websites[i].URL == "" {
+			config.Websites[i].URL = strings.Join(config.Websites[i].Command, " ")
+		}
+		if isDatabaseType(config.Websites[i].Type) && config.Websites[i].URL == "" {
+			config.Websites[i].URL = config.Websites[i].Type + " " + dsnLabel(config.Websites[i].DSN)
+		}
+		if config.Websites[i].Type == TypeDNS && config.Websites[i].RecordType == "" {
+			config.Websites[i].RecordType = "A"
+		}
 		if config.Websites[i].Method == "" {
 			config.Websites[i].Method = "GET"
 		}
 		if config.Websites[i].Timeout == 0 {
 			config.Websites[i].Timeout = config.Monitoring.Timeout
 		}
+		if config.Websites[i].Interval == 0 {
+			config.Websites[i].Interval = config.Monitoring.Interval
+		}
 	}
 
 	return &config, nil
@@ -144,18 +363,426 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("no websites configured")
 	}
 
+	if c.Monitoring.Retries < 0 {
+		return fmt.Errorf("retries must not be negative, got %d", c.Monitoring.Retries)
+	}
+
+	for _, days := range c.Monitoring.CertExpiryDays {
+		if days <= 0 {
+			return fmt.Errorf("cert_expiry_days must be positive, got %d", days)
+		}
+	}
+
+	pushTokens := make(map[string]bool)
 	for i, website := range c.Websites {
-		if website.URL == "" {
+		if website.URL == "" && website.Type != TypePush && website.Type != TypeExec && !isDatabaseType(website.Type) {
 			return fmt.Errorf("website %d: URL is required", i)
 		}
 		if website.Name == "" {
 			return fmt.Errorf("website %d: Name is required", i)
 		}
+		if website.Interval < 0 {
+			return fmt.Errorf("website %d: interval must be positive", i)
+		}
+		if website.Body != "" && website.BodyFile != "" {
+			return fmt.Errorf("website %d: body and body_file are mutually exclusive", i)
+		}
+		if website.BodyFile != "" {
+			if _, err := os.Stat(website.BodyFile); err != nil {
+				return fmt.Errorf("website %d: body_file: %w", i, err)
+			}
+		}
+		if website.MaxRedirects < 0 {
+			return fmt.Errorf("website %d: max_redirects must not be negative", i)
+		}
+		if website.ExpectedLocation != "" && (website.FollowRedirects == nil || *website.FollowRedirects) {
+			return fmt.Errorf("website %d: expected_location requires follow_redirects: false", i)
+		}
+		if website.WarnResponseTime < 0 || website.MaxResponseTime < 0 {
+			return fmt.Errorf("website %d: response time thresholds must be positive", i)
+		}
+		if website.WarnResponseTime > 0 && website.MaxResponseTime > 0 && website.WarnResponseTime >= website.MaxResponseTime {
+			return fmt.Errorf("website %d: warn_response_time must be lower than max_response_time", i)
+		}
+		if err := website.validateClient(); err != nil {
+			return fmt.Errorf("website %d: %w", i, err)
+		}
+		if website.Auth != nil {
+			switch website.Type {
+			case "", TypeHTTP, TypeTransaction:
+			case TypeSMTP, TypeIMAP, TypePOP3:
+				if website.Auth.Type != AuthBasic {
+					return fmt.Errorf("website %d: mail checks only support basic auth", i)
+				}
+			default:
+				return fmt.Errorf("website %d: auth is only supported for http, transaction and mail checks", i)
+			}
+			if err := website.Auth.validate(); err != nil {
+				return fmt.Errorf("website %d: auth: %w", i, err)
+			}
+		}
+		for _, expr := range []string{website.CheckContentRegex, website.MustNotContainRegex} {
+			if _, err := regexp.Compile(expr); err != nil {
+				return fmt.Errorf("website %d: invalid content regex: %w", i, err)
+			}
+		}
+		if err := website.validateChangeDetection(); err != nil {
+			return fmt.Errorf("website %d: %w", i, err)
+		}
+		if website.Schedule != "" {
+			if _, err := ParseSchedule(website.Schedule, website.Timezone); err != nil {
+				return fmt.Errorf("website %d: %w", i, err)
+			}
+		}
+
+		for j, rule := range website.ExpectedHeaders {
+			if err := rule.validate(); err != nil {
+				return fmt.Errorf("website %d: expected_headers %d: %w", i, j, err)
+			}
+		}
+
+		for j, assertion := range website.Assertions {
+			if err := assertion.validate(); err != nil {
+				return fmt.Errorf("website %d: assertion %d: %w", i, j, err)
+			}
+		}
+		if _, err := regexp.Compile(website.AnswerPattern); err != nil {
+			return fmt.Errorf("website %d: invalid answer_pattern: %w", i, err)
+		}
+		if len(website.Steps) > 0 && website.Type != TypeTransaction {
+			return fmt.Errorf("website %d: steps are only supported for transaction checks", i)
+		}
+
+		switch website.Type {
+		case "", TypeHTTP:
+		case TypeTCP:
+			if _, _, err := net.SplitHostPort(strings.TrimPrefix(website.URL, "tcp://")); err != nil {
+				return fmt.Errorf("website %d: tcp target must be host:port: %w", i, err)
+			}
+		case TypeTLS:
+			target := strings.TrimPrefix(website.URL, "tls://")
+			if strings.Contains(target, "/") {
+				return fmt.Errorf("website %d: tls target must be host or host:port", i)
+			}
+		case TypeTransaction:
+			if err := validateSteps(website.Steps); err != nil {
+				return fmt.Errorf("website %d: %w", i, err)
+			}
+		case TypeGRPC:
+			if _, _, err := net.SplitHostPort(strings.TrimPrefix(website.URL, "grpc://")); err != nil {
+				return fmt.Errorf("website %d: grpc target must be host:port: %w", i, err)
+			}
+		case TypeWebSocket:
+			if u, err := url.Parse(website.URL); err != nil || (u.Scheme != "ws" && u.Scheme != "wss") {
+				return fmt.Errorf("website %d: websocket url must start with ws:// or wss://", i)
+			}
+		case TypePostgres, TypeMySQL, TypeRedis:
+			if err := website.DSN.Validate(); err != nil {
+				return fmt.Errorf("website %d: dsn: %w", i, err)
+			}
+			if website.Type == TypeRedis && (website.Query != "" || website.ExpectedResult != "") {
+				return fmt.Errorf("website %d: query and expected_result are not supported for redis", i)
+			}
+			if website.Type != TypeRedis && website.ExpectedRole != "" {
+				return fmt.Errorf("website %d: expected_role is only supported for redis", i)
+			}
+			switch website.ExpectedRole {
+			case "", "master", "replica", "slave":
+			default:
+				return fmt.Errorf("website %d: expected_role must be master or replica", i)
+			}
+		case TypeSMTP, TypeIMAP, TypePOP3:
+			if err := validateMailURL(website.Type, website.URL); err != nil {
+				return fmt.Errorf("website %d: %w", i, err)
+			}
+			implicitTLS := strings.HasPrefix(website.URL, website.Type+"s://")
+			if website.StartTLS && implicitTLS {
+				return fmt.Errorf("website %d: starttls can't be combined with %ss://", i, website.Type)
+			}
+			if website.Auth != nil && !website.StartTLS && !implicitTLS {
+				return fmt.Errorf("website %d: mail auth requires starttls or %ss://", i, website.Type)
+			}
+		case TypeSSH:
+			target := strings.TrimPrefix(website.URL, "ssh://")
+			if target == "" || strings.Contains(target, "/") {
+				return fmt.Errorf("website %d: ssh target must be host or host:port", i)
+			}
+			if website.HostKeyFingerprint != "" && !strings.HasPrefix(website.HostKeyFingerprint, "SHA256:") {
+				return fmt.Errorf("website %d: host_key_fingerprint must be a SHA256:... fingerprint", i)
+			}
+		case TypeExec:
+			if len(website.Command) == 0 || website.Command[0] == "" {
+				return fmt.Errorf("website %d: exec checks require a command", i)
+			}
+		case TypePush:
+			if !c.Web.Enabled {
+				return fmt.Errorf("website %d: push checks require web.enabled", i)
+			}
+			if !pushTokenPattern.MatchString(website.PushToken) {
+				return fmt.Errorf("website %d: push_token is required and may only contain letters, digits, '-' and '_'", i)
+			}
+			if pushTokens[website.PushToken] {
+				return fmt.Errorf("website %d: push_token is already used by another website", i)
+			}
+			pushTokens[website.PushToken] = true
+			if website.Period <= 0 {
+				return fmt.Errorf("website %d: push checks require a positive period", i)
+			}
+			if website.Grace < 0 {
+				return fmt.Errorf("website %d: grace must not be negative", i)
+			}
+		case TypeDNS:
+			if website.RecordType != "" && !dnsRecordTypes[strings.ToUpper(website.RecordType)] {
+				return fmt.Errorf("website %d: unsupported record_type %q", i, website.RecordType)
+			}
+		default:
+			return fmt.Errorf("website %d: unknown type %q", i, website.Type)
+		}
+	}
+
+	return nil
+}
+
+// validateClient checks the proxy and TLS client settings
+func (w WebsiteConfig) validateClient() error {
+	if w.Proxy != "" {
+		u, err := url.Parse(w.Proxy)
+		if err != nil {
+			return fmt.Errorf("invalid proxy: %w", err)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return fmt.Errorf("proxy scheme must be http, https or socks5, got %q", u.Scheme)
+		}
+	}
+
+	if (w.ClientCert == "") != (w.ClientKey == "") {
+		return fmt.Errorf("client_cert and client_key must be set together")
+	}
+
+	for _, file := range []string{w.CAFile, w.ClientCert, w.ClientKey} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateChangeDetection checks the content change detection settings
+func (w WebsiteConfig) validateChangeDetection() error {
+	if !w.DetectChanges {
+		if len(w.IgnoreRegions) > 0 || w.BaselineFile != "" {
+			return fmt.Errorf("ignore_regions and baseline_file require detect_changes")
+		}
+		return nil
+	}
+	if w.Type != "" && w.Type != TypeHTTP {
+		return fmt.Errorf("detect_changes is only supported for http checks")
+	}
+	for _, expr := range w.IgnoreRegions {
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("invalid ignore_regions pattern: %w", err)
+		}
+	}
+	if w.BaselineFile != "" {
+		if _, err := os.Stat(w.BaselineFile); err != nil {
+			return fmt.Errorf("baseline_file: %w", err)
+		}
+	}
+	return nil
+}
+
+// validate checks that the header rule is usable
+func (r HeaderRuleConfig) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if r.Absent && (r.Equals != "" || r.Matches != "") {
+		return fmt.Errorf("absent cannot be combined with equals or matches")
+	}
+	if r.Matches != "" {
+		if _, err := regexp.Compile(r.Matches); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateMailURL checks that a mail url is host[:port], optionally with the
+// protocol's scheme or its implicit TLS variant
+func validateMailURL(checkType, rawURL string) error {
+	target := rawURL
+	if scheme, rest, found := strings.Cut(rawURL, "://"); found {
+		if scheme != checkType && scheme != checkType+"s" {
+			return fmt.Errorf("%s url scheme must be %s:// or %ss://", checkType, checkType, checkType)
+		}
+		target = strings.TrimSuffix(rest, "/")
+	}
+	if target == "" || strings.Contains(target, "/") {
+		return fmt.Errorf("%s target must be host or host:port", checkType)
+	}
+	return nil
+}
+
+// isDatabaseType reports whether a check type connects to a data store
+func isDatabaseType(checkType string) bool {
+	return checkType == TypePostgres || checkType == TypeMySQL || checkType == TypeRedis
+}
+
+// dsnLabel describes where a DSN comes from without revealing it
+func dsnLabel(dsn secret.Ref) string {
+	if dsn.File != "" {
+		return dsn.File
+	}
+	return "$" + dsn.Env
+}
+
+// validateSteps checks a transaction's steps, and that every {{name}} they
+// use is extracted by an earlier step
+func validateSteps(steps []StepConfig) error {
+	if len(steps) == 0 {
+		return fmt.Errorf("transaction requires at least one step")
+	}
+
+	extracted := make(map[string]bool)
+	for j, step := range steps {
+		if step.URL == "" {
+			return fmt.Errorf("step %d: url is required", j)
+		}
+
+		refs := []string{step.URL, step.Body}
+		for _, value := range step.Headers {
+			refs = append(refs, value)
+		}
+		for _, ref := range refs {
+			for _, name := range stepvar.Names(ref) {
+				if !extracted[name] {
+					return fmt.Errorf("step %d: {{%s}} is not extracted by an earlier step", j, name)
+				}
+			}
+		}
+
+		for k, assertion := range step.Assertions {
+			if err := assertion.validate(); err != nil {
+				return fmt.Errorf("step %d: assertion %d: %w", j, k, err)
+			}
+		}
+
+		for _, extract := range step.Extract {
+			if err := extract.validate(); err != nil {
+				return fmt.Errorf("step %d: extract %q: %w", j, extract.Name, err)
+			}
+			extracted[extract.Name] = true
+		}
+	}
+	return nil
+}
+
+// validate checks that the extraction has a name and exactly one source
+func (e ExtractConfig) validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	sources := 0
+	for _, source := range []string{e.JSON, e.Header, e.Cookie} {
+		if source != "" {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("exactly one of json, header or cookie is required")
+	}
+
+	if e.JSON != "" {
+		if _, err := jsonpath.Parse(e.JSON); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks that the fields required by the auth type are set
+func (a AuthConfig) validate() error {
+	switch a.Type {
+	case AuthBasic:
+		if a.Username == "" {
+			return fmt.Errorf("basic auth requires a username")
+		}
+		if err := a.Password.Validate(); err != nil {
+			return fmt.Errorf("password: %w", err)
+		}
+	case AuthBearer:
+		if err := a.Token.Validate(); err != nil {
+			return fmt.Errorf("token: %w", err)
+		}
+	case AuthOAuth2:
+		if a.TokenURL == "" || a.ClientID == "" {
+			return fmt.Errorf("oauth2 requires token_url and client_id")
+		}
+		if _, err := url.ParseRequestURI(a.TokenURL); err != nil {
+			return fmt.Errorf("invalid token_url: %w", err)
+		}
+		if err := a.ClientSecret.Validate(); err != nil {
+			return fmt.Errorf("client_secret: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown type %q", a.Type)
+	}
+	return nil
+}
+
+// validate checks that the assertion's path, operator and value are usable
+func (a AssertionConfig) validate() error {
+	if _, err := jsonpath.Parse(a.Path); err != nil {
+		return err
+	}
+
+	needsValue, ok := assertionOperators[a.Operator]
+	if !ok {
+		return fmt.Errorf("unknown operator %q", a.Operator)
+	}
+	if needsValue && a.Value == "" {
+		return fmt.Errorf("operator %s requires a value", a.Operator)
+	}
+
+	switch a.Operator {
+	case "greater_than", "less_than":
+		if _, err := strconv.ParseFloat(a.Value, 64); err != nil {
+			return fmt.Errorf("operator %s requires a number, got %q", a.Operator, a.Value)
+		}
+	case "matches":
+		if _, err := regexp.Compile(a.Value); err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// ParseSchedule parses a standard 5-field cron expression (or descriptor
+// such as @hourly) evaluated in the given timezone
+func ParseSchedule(spec, timezone string) (cron.Schedule, error) {
+	expr := spec
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+		expr = "CRON_TZ=" + timezone + " " + spec
+	}
+
+	schedule, err := cron.ParseStandard(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+	}
+
+	return schedule, nil
+}
+
 // SaveConfig saves the configuration to a file
 func SaveConfig(cfg *Config, path string) error {
 	data, err := yaml.Marshal(cfg)
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// loadYAML writes a config file and loads it
+func loadYAML(t *testing.T, data string) *Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return cfg
+}
+
+func TestLoadRetries(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want int
+	}{
+		{"default", "websites: []\n", 3},
+		{"disabled", "monitoring:\n  retries: 0\n", 0},
+		{"set", "monitoring:\n  retries: 5\n", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loadYAML(t, tt.yaml).Monitoring.Retries; got != tt.want {
+				t.Errorf("retries = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRejectsBadHeaderRegex(t *testing.T) {
+	cfg := &Config{Websites: []WebsiteConfig{{
+		Name:            "site",
+		URL:             "https://example.com",
+		ExpectedHeaders: []HeaderRuleConfig{{Name: "Server", Matches: "nginx("}},
+	}}}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate accepted an invalid header regex")
+	}
+}
+
+func TestValidateRejectsBadPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		website WebsiteConfig
+	}{
+		{"assertion path", WebsiteConfig{
+			Assertions: []AssertionConfig{{Path: "$.items[x]", Operator: "exists"}},
+		}},
+		{"assertion regex", WebsiteConfig{
+			Assertions: []AssertionConfig{{Path: "$.version", Operator: "matches", Value: "v("}},
+		}},
+		{"extract path", WebsiteConfig{
+			Type:  TypeTransaction,
+			Steps: []StepConfig{{URL: "/login", Extract: []ExtractConfig{{Name: "token", JSON: "$..token"}}}},
+		}},
+		{"steps outside a transaction", WebsiteConfig{
+			Type:  TypeHTTP,
+			Steps: []StepConfig{{URL: "/login"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.website.Name = "site"
+			tt.website.URL = "https://example.com"
+			cfg := &Config{Websites: []WebsiteConfig{tt.website}}
+			if err := cfg.Validate(); err == nil {
+				t.Error("Validate accepted an invalid website")
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// segment is a single step of a path: an object key or an array index
+type segment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// Path is a parsed JSONPath-like expression such as $.db.healthy,
+// $.items[0].id or $['content-type']
+type Path struct {
+	expr     string
+	segments []segment
+}
+
+// Parse parses a path expression. The leading "$" is optional.
+func Parse(expr string) (Path, error) {
+	path := Path{expr: expr}
+
+	s := strings.TrimSpace(expr)
+	s = strings.TrimPrefix(s, "$")
+	if s != "" && s[0] != '.' && s[0] != '[' {
+		s = "." + s
+	}
+
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end == -1 {
+				end = len(s)
+			}
+			if end == 0 {
+				return Path{}, fmt.Errorf("invalid path %q: empty key", expr)
+			}
+			path.segments = append(path.segments, segment{key: s[:end]})
+			s = s[end:]
+
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end == -1 {
+				return Path{}, fmt.Errorf("invalid path %q: missing ']'", expr)
+			}
+			inner := strings.TrimSpace(s[1:end])
+			s = s[end+1:]
+
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				path.segments = append(path.segments, segment{key: inner[1 : len(inner)-1]})
+				continue
+			}
+
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return Path{}, fmt.Errorf("invalid path %q: bad index %q", expr, inner)
+			}
+			path.segments = append(path.segments, segment{index: index, isIndex: true})
+
+		default:
+			return Path{}, fmt.Errorf("invalid path %q: unexpected %q", expr, s[0])
+		}
+	}
+
+	return path, nil
+}
+
+// MustParse is like Parse but panics if the expression is invalid. It is
+// meant for expressions that were already checked.
+func MustParse(expr string) Path {
+	path, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return path
+}
+
+// Lookup returns the value at the path in a document decoded with
+// encoding/json, and whether it exists
+func (p Path) Lookup(doc any) (any, bool) {
+	current := doc
+
+	for _, seg := range p.segments {
+		if seg.isIndex {
+			list, ok := current.([]any)
+			if !ok || seg.index >= len(list) {
+				return nil, false
+			}
+			current = list[seg.index]
+			continue
+		}
+
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[seg.key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// String returns the original expression
+func (p Path) String() string {
+	return p.expr
+}
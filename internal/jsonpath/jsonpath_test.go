@@ -0,0 +1,105 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    []segment
+		wantErr bool
+	}{
+		{expr: "$", want: nil},
+		{expr: "", want: nil},
+		{expr: "$.db.healthy", want: []segment{{key: "db"}, {key: "healthy"}}},
+		{expr: "db.healthy", want: []segment{{key: "db"}, {key: "healthy"}}},
+		{expr: "$.items[0].id", want: []segment{{key: "items"}, {index: 0, isIndex: true}, {key: "id"}}},
+		{expr: "$[2]", want: []segment{{index: 2, isIndex: true}}},
+		{expr: "$['content-type']", want: []segment{{key: "content-type"}}},
+		{expr: `$["a.b"][ 1 ]`, want: []segment{{key: "a.b"}, {index: 1, isIndex: true}}},
+		{expr: "$..a", wantErr: true},
+		{expr: "$.a.", wantErr: true},
+		{expr: "$.items[0", wantErr: true},
+		{expr: "$.items[-1]", wantErr: true},
+		{expr: "$.items[x]", wantErr: true},
+		{expr: "$.items['x]", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			path, err := Parse(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(path.segments, tt.want) {
+				t.Errorf("segments = %+v, want %+v", path.segments, tt.want)
+			}
+			if path.String() != tt.expr {
+				t.Errorf("String() = %q, want %q", path.String(), tt.expr)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	var doc any
+	err := json.Unmarshal([]byte(`{
+		"status": "ok",
+		"db": {"healthy": true, "latency": 12.5},
+		"items": [{"id": 1}, {"id": 2, "tags": ["a", "b"]}],
+		"content-type": "application/json",
+		"empty": null
+	}`), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		expr  string
+		want  any
+		found bool
+	}{
+		{expr: "$.status", want: "ok", found: true},
+		{expr: "$.db.healthy", want: true, found: true},
+		{expr: "$.db.latency", want: 12.5, found: true},
+		{expr: "$.items[1].id", want: 2.0, found: true},
+		{expr: "$.items[1].tags[0]", want: "a", found: true},
+		{expr: "$['content-type']", want: "application/json", found: true},
+		{expr: "$.empty", want: nil, found: true},
+		{expr: "$.db", want: map[string]any{"healthy": true, "latency": 12.5}, found: true},
+		{expr: "$", want: doc, found: true},
+		{expr: "$.missing"},
+		{expr: "$.db.missing"},
+		{expr: "$.items[5]"},
+		{expr: "$.items.id"},
+		{expr: "$.status[0]"},
+		{expr: "$.empty.key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, found := MustParse(tt.expr).Lookup(doc)
+			if found != tt.found {
+				t.Fatalf("found = %v, want %v", found, tt.found)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMustParsePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParse did not panic on an invalid path")
+		}
+	}()
+	MustParse("$.items[")
+}
@@ -0,0 +1,103 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/ravikantchauhan246/ospy/internal/jsonpath"
+)
+
+// Assertion checks a value in a JSON response body
+type Assertion struct {
+	Path     jsonpath.Path
+	Operator string
+	Value    string
+	Pattern  *regexp.Regexp // Value compiled, for the matches operator
+}
+
+// checkAssertions evaluates assertions against a JSON body and returns an
+// error describing the first one that fails
+func checkAssertions(body []byte, assertions []Assertion) error {
+	var doc any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return fmt.Errorf("response is not valid JSON: %w", err)
+	}
+
+	for _, assertion := range assertions {
+		if err := assertion.evaluate(doc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// evaluate applies the assertion to a decoded JSON document
+func (a Assertion) evaluate(doc any) error {
+	value, found := a.Path.Lookup(doc)
+
+	switch a.Operator {
+	case "exists":
+		if !found {
+			return fmt.Errorf("%s does not exist", a.Path)
+		}
+		return nil
+	case "not_exists":
+		if found {
+			return fmt.Errorf("%s exists (got %s)", a.Path, formatValue(value))
+		}
+		return nil
+	}
+
+	if !found {
+		return fmt.Errorf("%s %s %s (not found)", a.Path, a.Operator, a.Value)
+	}
+	got := formatValue(value)
+
+	var ok bool
+	switch a.Operator {
+	case "equals":
+		ok = got == a.Value
+	case "not_equals":
+		ok = got != a.Value
+	case "greater_than", "less_than":
+		number, isNumber := value.(float64)
+		if !isNumber {
+			var err error
+			number, err = strconv.ParseFloat(got, 64)
+			if err != nil {
+				return fmt.Errorf("%s %s %s (got non-numeric %s)", a.Path, a.Operator, a.Value, got)
+			}
+		}
+		expected, err := strconv.ParseFloat(a.Value, 64)
+		if err != nil {
+			return fmt.Errorf("%s %s: invalid number %q", a.Path, a.Operator, a.Value)
+		}
+		if a.Operator == "greater_than" {
+			ok = number > expected
+		} else {
+			ok = number < expected
+		}
+	case "matches":
+		ok = a.Pattern.MatchString(got)
+	default:
+		return fmt.Errorf("unknown operator %q", a.Operator)
+	}
+
+	if !ok {
+		return fmt.Errorf("%s %s %s (got %s)", a.Path, a.Operator, a.Value, got)
+	}
+	return nil
+}
+
+// formatValue renders a decoded JSON value the way it would be written in
+// the config: strings without quotes, other values as JSON
+func formatValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/ravikantchauhan246/ospy/internal/jsonpath"
+)
+
+func TestCheckAssertions(t *testing.T) {
+	body := []byte(`{"status": "ok", "db": {"healthy": true, "latency_ms": 12}, "version": "1.4.2", "items": [{"id": 7}]}`)
+
+	tests := []struct {
+		path     string
+		operator string
+		value    string
+		ok       bool
+	}{
+		{"$.status", "equals", "ok", true},
+		{"$.status", "equals", "down", false},
+		{"$.status", "not_equals", "down", true},
+		{"$.db.healthy", "equals", "true", true},
+		{"$.db.latency_ms", "less_than", "100", true},
+		{"$.db.latency_ms", "greater_than", "100", false},
+		{"$.version", "greater_than", "1", false}, // Not a number
+		{"$.version", "matches", `^1\.\d+\.\d+$`, true},
+		{"$.version", "matches", `^2\.`, false},
+		{"$.items[0].id", "equals", "7", true},
+		{"$.items[1].id", "exists", "", false},
+		{"$.error", "not_exists", "", true},
+		{"$.status", "not_exists", "", false},
+		{"$.missing", "equals", "x", false},
+	}
+	for _, tt := range tests {
+		assertion := Assertion{Path: jsonpath.MustParse(tt.path), Operator: tt.operator, Value: tt.value}
+		if tt.operator == "matches" {
+			assertion.Pattern = regexp.MustCompile(tt.value)
+		}
+		err := checkAssertions(body, []Assertion{assertion})
+		if (err == nil) != tt.ok {
+			t.Errorf("%s %s %s: got %v, want ok %v", tt.path, tt.operator, tt.value, err, tt.ok)
+		}
+	}
+
+	if err := checkAssertions([]byte("<html>"), nil); err == nil {
+		t.Error("non-JSON body accepted")
+	}
+}
@@ -0,0 +1,112 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ravikantchauhan246/ospy/internal/secret"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// Auth adds credentials to a website's HTTP requests
+type Auth struct {
+	Type string // basic, bearer or oauth2
+
+	Username string
+	Password secret.Ref
+
+	Token secret.Ref
+
+	TokenURL     string
+	ClientID     string
+	ClientSecret secret.Ref
+	Scopes       []string
+}
+
+// tokenSourceKey identifies a cached OAuth2 token source. The secret is part
+// of the key so a rotated secret gets a fresh token.
+type tokenSourceKey struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       string
+	transport    transportKey
+}
+
+// authorize adds the website's credentials to the request. client is used
+// to fetch OAuth2 tokens so the token endpoint gets the same proxy and TLS
+// settings as the website.
+func (c *Checker) authorize(req *http.Request, website Website, client *http.Client) error {
+	auth := website.Auth
+	if auth == nil {
+		return nil
+	}
+
+	switch auth.Type {
+	case "basic":
+		password, err := auth.Password.Value()
+		if err != nil {
+			return fmt.Errorf("password: %w", err)
+		}
+		req.SetBasicAuth(auth.Username, password)
+
+	case "bearer":
+		token, err := auth.Token.Value()
+		if err != nil {
+			return fmt.Errorf("token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+
+	case "oauth2":
+		clientSecret, err := auth.ClientSecret.Value()
+		if err != nil {
+			return fmt.Errorf("client secret: %w", err)
+		}
+		token, err := c.tokenSource(website, clientSecret, client).Token()
+		if err != nil {
+			return fmt.Errorf("failed to fetch token: %w", err)
+		}
+		token.SetAuthHeader(req)
+
+	default:
+		return fmt.Errorf("unknown auth type %q", auth.Type)
+	}
+
+	return nil
+}
+
+// tokenSource returns the cached client-credentials token source for a
+// website. Tokens are reused until they expire, then fetched again.
+func (c *Checker) tokenSource(website Website, clientSecret string, client *http.Client) oauth2.TokenSource {
+	auth := website.Auth
+	key := tokenSourceKey{
+		tokenURL:     auth.TokenURL,
+		clientID:     auth.ClientID,
+		clientSecret: clientSecret,
+		scopes:       strings.Join(auth.Scopes, " "),
+		transport:    transportKeyFor(website),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if source, ok := c.tokenSources[key]; ok {
+		return source
+	}
+
+	config := clientcredentials.Config{
+		ClientID:     auth.ClientID,
+		ClientSecret: clientSecret,
+		TokenURL:     auth.TokenURL,
+		Scopes:       auth.Scopes,
+	}
+	// Only keep the transport; the source outlives the check's cookies
+	tokenClient := &http.Client{Timeout: client.Timeout, Transport: client.Transport}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, tokenClient)
+	source := config.TokenSource(ctx)
+	c.tokenSources[key] = source
+	return source
+}
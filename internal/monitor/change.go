@@ -0,0 +1,154 @@
+package monitor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Limits on the diff snippet sent with a content change
+const (
+	maxDiffLines    = 40
+	maxDiffLineSize = 200
+)
+
+// contentState is what change detection remembers about a website's last check
+type contentState struct {
+	hash string
+	body string
+
+	// restored is set for a hash loaded from storage, which has no body
+	restored bool
+}
+
+// SetContentHash restores the content hash stored by a website's last check,
+// so a change made while ospy wasn't running is still detected
+func (c *Checker) SetContentHash(websiteName, hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.contents[websiteName] = contentState{hash: hash, restored: true}
+}
+
+// detectChange hashes the normalized body and compares it with the pinned
+// baseline, or with the previous check when there is none. A change is
+// reported once, when the content first differs; a page that keeps differing
+// from its baseline in the same way isn't reported again. The new content is
+// only kept by saveContent.
+func (c *Checker) detectChange(result *CheckResult, website Website, body []byte) error {
+	content := normalizeContent(string(body), website.IgnoreRegions)
+	hash := contentHash(content)
+	result.ContentHash = hash
+
+	result.content = &contentState{hash: hash, body: content}
+
+	c.mu.Lock()
+	previous, seen := c.contents[website.Name]
+	c.mu.Unlock()
+
+	if seen && previous.hash == hash {
+		return nil
+	}
+
+	reference, label := previous.body, "previous"
+	if website.BaselineFile != "" {
+		data, err := os.ReadFile(website.BaselineFile)
+		if err != nil {
+			return fmt.Errorf("failed to read baseline file: %w", err)
+		}
+		reference = normalizeContent(string(data), website.IgnoreRegions)
+		if contentHash(reference) == hash {
+			return nil
+		}
+		label = "baseline"
+	} else if !seen {
+		return nil // Nothing to compare the first check with
+	}
+
+	result.ContentChanged = true
+	if website.BaselineFile == "" && previous.restored {
+		// Only the hash of the previous content is known
+		result.ContentDiff = "Content changed since the last check before restart"
+		return nil
+	}
+	result.ContentDiff = unifiedDiff(reference, content, label)
+	return nil
+}
+
+// saveContent keeps the content of a check for the next comparison. It runs
+// once the response time thresholds are applied: a check they turn DOWN is
+// treated like any other failure, so its content is neither compared nor
+// kept, and a retry still sees the change.
+func (c *Checker) saveContent(website Website, result *CheckResult) {
+	state := result.content
+	if state == nil {
+		return
+	}
+	result.content = nil
+
+	if !result.IsUp {
+		result.ContentHash = ""
+		result.ContentChanged = false
+		result.ContentDiff = ""
+		return
+	}
+
+	c.mu.Lock()
+	c.contents[website.Name] = *state
+	c.mu.Unlock()
+}
+
+// normalizeContent removes the regions matched by the ignore patterns, such
+// as timestamps or CSRF tokens, so they don't count as changes. Trailing
+// whitespace is dropped too, as it's often left behind by a removed region.
+func normalizeContent(content string, ignore []*regexp.Regexp) string {
+	for _, pattern := range ignore {
+		content = pattern.ReplaceAllString(content, "")
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// contentHash returns the hex SHA-256 of content
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// unifiedDiff returns a unified diff from old to new, cut to maxDiffLines
+// lines of at most maxDiffLineSize bytes
+func unifiedDiff(old, new, label string) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(old),
+		B:        difflib.SplitLines(new),
+		FromFile: label,
+		ToFile:   "current",
+		Context:  2,
+	})
+	if err != nil {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	truncated := len(lines) > maxDiffLines
+	if truncated {
+		lines = lines[:maxDiffLines]
+	}
+	for i, line := range lines {
+		if len(line) > maxDiffLineSize {
+			lines[i] = line[:maxDiffLineSize] + "..."
+		}
+	}
+	if truncated {
+		lines = append(lines, "...")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,70 @@
+package monitor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestChangeDetectedAfterSlowCheck(t *testing.T) {
+	body, delay := "version 1", time.Duration(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		io.WriteString(w, body)
+	}))
+	defer server.Close()
+
+	checker := NewChecker(5 * time.Second)
+	website := Website{Name: "page", URL: server.URL, Method: "GET", DetectChanges: true}
+	check := func() CheckResult {
+		return checker.CheckWebsite(context.Background(), website)
+	}
+
+	if result := check(); !result.IsUp || result.ContentChanged {
+		t.Fatalf("first check: up %v, changed %v", result.IsUp, result.ContentChanged)
+	}
+
+	// A change seen by a check that max_response_time turns DOWN is not kept
+	body, delay = "version 2", 50*time.Millisecond
+	website.MaxResponseTime = 10 * time.Millisecond
+	if result := check(); result.IsUp || result.ContentChanged {
+		t.Fatalf("slow check: up %v, changed %v", result.IsUp, result.ContentChanged)
+	}
+
+	// so the retry still reports it
+	delay = 0
+	website.MaxResponseTime = 0
+	result := check()
+	if !result.IsUp || !result.ContentChanged {
+		t.Fatalf("retry: up %v, changed %v", result.IsUp, result.ContentChanged)
+	}
+	if want := "--- previous\n+++ current\n@@ -1 +1 @@\n-version 1\n+version 2"; result.ContentDiff != want {
+		t.Errorf("diff %q, want %q", result.ContentDiff, want)
+	}
+
+	if result := check(); result.ContentChanged {
+		t.Errorf("unchanged content reported as changed")
+	}
+}
+
+func TestChangeAfterRestart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "new")
+	}))
+	defer server.Close()
+
+	checker := NewChecker(5 * time.Second)
+	checker.SetContentHash("page", contentHash("old"))
+	website := Website{Name: "page", URL: server.URL, Method: "GET", DetectChanges: true}
+
+	result := checker.CheckWebsite(context.Background(), website)
+	if !result.ContentChanged {
+		t.Fatal("change since the stored hash not reported")
+	}
+	if result.ContentHash != contentHash("new") {
+		t.Errorf("hash %s, want the hash of the new content", result.ContentHash)
+	}
+}
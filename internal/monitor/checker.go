@@ -5,8 +5,13 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/oauth2"
 )
 
 // CheckResult represents the result of a website check
@@ -18,12 +23,59 @@ type CheckResult struct {
 	Error        error
 	Timestamp    time.Time
 	IsUp         bool
+	Degraded     bool // Up, but slower than the warning threshold
+	Unknown      bool // Down because the state couldn't be determined
 	Message      string
+	Attempts     int // Number of attempts made, including retries
+
+	// Redirects followed (or not followed) on the way to the final response
+	RedirectChain []RedirectHop
+
+	// Leaf certificate details for TLS connections
+	CertExpiry time.Time
+	CertIssuer string
+	CertSANs   []string
+
+	// Phase breakdown of HTTP checks
+	Timing Timing
+
+	// Steps of a transaction check, up to and including the one that failed
+	Steps      []StepResult
+	FailedStep string
+
+	// Performance data reported by exec checks
+	Metrics []Metric
+
+	// WebSocket upgrade time, and time from sending to the expected reply
+	Handshake time.Duration
+	RoundTrip time.Duration
+
+	// SHA256 fingerprint of an SSH server's host key. SecurityAlert marks a
+	// fingerprint mismatch, which is alerted on separately from an outage.
+	HostKey       string
+	SecurityAlert bool
+
+	// Hash of the normalized body when change detection is on, and a
+	// unified diff when it changed
+	ContentHash    string
+	ContentChanged bool
+	ContentDiff    string
+
+	// content is the change detection state to keep if the result stays up
+	content *contentState
 }
 
 // Checker handles HTTP requests to websites
 type Checker struct {
-	client *http.Client
+	client  *http.Client
+	timeout time.Duration
+
+	// Transports for websites with their own proxy or TLS settings, OAuth2
+	// token sources, and the last content of websites with change detection
+	mu           sync.Mutex
+	transports   map[transportKey]*http.Transport
+	tokenSources map[tokenSourceKey]oauth2.TokenSource
+	contents     map[string]contentState
 }
 
 // NewChecker creates a new HTTP checker with specified timeout
@@ -32,6 +84,10 @@ func NewChecker(timeout time.Duration) *Checker {
 		client: &http.Client{
 			Timeout: timeout,
 		},
+		timeout:      timeout,
+		transports:   make(map[transportKey]*http.Transport),
+		tokenSources: make(map[tokenSourceKey]oauth2.TokenSource),
+		contents:     make(map[string]contentState),
 	}
 }
 
@@ -45,16 +101,114 @@ func (c *Checker) Check(ctx context.Context, url string) CheckResult {
 	return c.CheckWebsite(ctx, website)
 }
 
-// CheckWebsite performs an HTTP request to the given website
+// CheckWebsite runs the check matching the website's type
 func (c *Checker) CheckWebsite(ctx context.Context, website Website) CheckResult {
-	start := time.Now()
-	
+	var result CheckResult
+
+	switch website.Type {
+	case "tcp":
+		result = c.checkTCP(ctx, website)
+	case "dns":
+		result = c.checkDNS(ctx, website)
+	case "tls":
+		result = c.checkTLS(ctx, website)
+	case "transaction":
+		result = c.checkTransaction(ctx, website)
+	case "exec":
+		result = c.checkExec(ctx, website)
+	case "grpc":
+		result = c.checkGRPC(ctx, website)
+	case "websocket":
+		result = c.checkWebSocket(ctx, website)
+	case "postgres", "mysql":
+		result = c.checkSQL(ctx, website)
+	case "redis":
+		result = c.checkRedis(ctx, website)
+	case "smtp", "imap", "pop3":
+		result = c.checkMail(ctx, website)
+	case "ssh":
+		result = c.checkSSH(ctx, website)
+	default:
+		result = c.checkHTTP(ctx, website)
+	}
+
+	applyResponseTimeThresholds(&result, website)
+	c.saveContent(website, &result)
+	return result
+}
+
+// envPattern matches ${VAR} references in request bodies
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// requestBody returns the website's request body, read from body_file if
+// set, with ${VAR} references replaced by environment variables. It returns
+// nil when the website has no body.
+func requestBody(website Website) (io.Reader, error) {
+	body := website.Body
+	if website.BodyFile != "" {
+		data, err := os.ReadFile(website.BodyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read body file: %w", err)
+		}
+		body = string(data)
+	}
+
+	if body == "" {
+		return nil, nil
+	}
+
+	return strings.NewReader(expandEnv(body)), nil
+}
+
+// expandEnv replaces ${VAR} references with environment variable values.
+// Bare $VAR is left alone so payloads like GraphQL variables pass through.
+func expandEnv(s string) string {
+	return envPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		return os.Getenv(ref[2 : len(ref)-1])
+	})
+}
+
+// applyResponseTimeThresholds marks a successful check DOWN when it took
+// longer than the maximum response time, or DEGRADED when it took longer
+// than the warning threshold
+func applyResponseTimeThresholds(result *CheckResult, website Website) {
+	if !result.IsUp {
+		return
+	}
+
+	if website.MaxResponseTime > 0 && result.ResponseTime > website.MaxResponseTime {
+		result.IsUp = false
+		result.Message = fmt.Sprintf("Response time %v exceeded maximum %v", result.ResponseTime, website.MaxResponseTime)
+		return
+	}
+
+	if website.WarnResponseTime > 0 && result.ResponseTime > website.WarnResponseTime {
+		result.Degraded = true
+		result.Message = fmt.Sprintf("%s, slow response %v (warning at %v)", result.Message, result.ResponseTime, website.WarnResponseTime)
+	}
+}
+
+// checkHTTP performs an HTTP request to the given website
+func (c *Checker) checkHTTP(ctx context.Context, website Website) CheckResult {
+	reqBody, err := requestBody(website)
+	if err != nil {
+		return CheckResult{
+			WebsiteName: website.Name,
+			URL:         website.URL,
+			Error:       err,
+			Timestamp:   time.Now(),
+			IsUp:        false,
+			Message:     "Failed to load request body",
+		}
+	}
+
 	method := website.Method
 	if method == "" {
 		method = "GET"
 	}
 	
-	req, err := http.NewRequestWithContext(ctx, method, website.URL, nil)
+	tracer := &phaseTracer{}
+	req, err := http.NewRequestWithContext(tracer.withTrace(ctx), method, website.URL, reqBody)
 	if err != nil {
 		return CheckResult{
 			WebsiteName: website.Name,
@@ -66,12 +220,45 @@ func (c *Checker) CheckWebsite(ctx context.Context, website Website) CheckResult
 		}
 	}
 
+	if reqBody != nil && website.ContentType != "" {
+		req.Header.Set("Content-Type", website.ContentType)
+	}
+
 	// Add custom headers
 	for key, value := range website.Headers {
 		req.Header.Set(key, value)
 	}
 
-	resp, err := c.client.Do(req)
+	baseClient, err := c.httpClient(website)
+	if err != nil {
+		return CheckResult{
+			WebsiteName: website.Name,
+			URL:         website.URL,
+			Error:       err,
+			Timestamp:   time.Now(),
+			IsUp:        false,
+			Message:     "Failed to configure HTTP client",
+		}
+	}
+
+	if err := c.authorize(req, website, baseClient); err != nil {
+		return CheckResult{
+			WebsiteName: website.Name,
+			URL:         website.URL,
+			Error:       err,
+			Timestamp:   time.Now(),
+			IsUp:        false,
+			Message:     "Failed to authenticate",
+		}
+	}
+
+	var chain []RedirectHop
+	client := *baseClient
+	client.CheckRedirect = redirectPolicy(website, &chain)
+
+	// Start timing after any token fetch
+	start := time.Now()
+	resp, err := client.Do(req)
 	responseTime := time.Since(start)
 
 	result := CheckResult{
@@ -85,39 +272,77 @@ func (c *Checker) CheckWebsite(ctx context.Context, website Website) CheckResult
 		result.Error = fmt.Errorf("request failed: %w", err)
 		result.IsUp = false
 		result.Message = "HTTP request failed"
+		result.RedirectChain = chain
+		result.Timing = tracer.done()
 		return result
 	}
 	defer resp.Body.Close()
 
 	result.Status = resp.StatusCode
-
-	// Check if status is expected
-	expectedStatus := website.ExpectedStatus
-	if expectedStatus == 0 {
-		expectedStatus = 200
+	if resp.TLS != nil {
+		result.setCertificate(resp.TLS.PeerCertificates)
 	}
 
-	if resp.StatusCode == expectedStatus {
+	// Check if status is expected (200 unless configured)
+	if website.ExpectedStatus.Match(resp.StatusCode) {
 		result.IsUp = true
 		result.Message = fmt.Sprintf("Status %d (as expected)", resp.StatusCode)
 	} else {
 		result.IsUp = false
-		result.Message = fmt.Sprintf("Status %d (expected %d)", resp.StatusCode, expectedStatus)
+		result.Message = fmt.Sprintf("Status %d (expected %s)", resp.StatusCode, website.ExpectedStatus)
+	}
+
+	checkRedirects(&result, resp, website, chain)
+
+	// Check response headers
+	if result.IsUp && len(website.ExpectedHeaders) > 0 {
+		if err := checkHeaders(resp.Header, website.ExpectedHeaders); err != nil {
+			result.IsUp = false
+			result.Message = fmt.Sprintf("Header check failed: %v", err)
+		}
+	}
+
+	// Only keep the body when something needs to inspect it, but always
+	// read it so the transfer time is measured
+	if !needsBody(website) {
+		io.Copy(io.Discard, resp.Body)
+		result.Timing = tracer.done()
+		return result
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	result.Timing = tracer.done()
+	if err != nil {
+		result.Error = fmt.Errorf("failed to read response body: %w", err)
+		result.IsUp = false
+		result.Message = "Failed to read response body"
+		return result
 	}
 
 	// Check content if specified
-	if website.CheckContent != "" {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			result.Error = fmt.Errorf("failed to read response body: %w", err)
+	if err := checkContent(body, website); err != nil {
+		result.IsUp = false
+		result.Message = fmt.Sprintf("Content check failed: %v", err)
+	}
+
+	// Evaluate JSON assertions
+	if result.IsUp && len(website.Assertions) > 0 {
+		if err := checkAssertions(body, website.Assertions); err != nil {
 			result.IsUp = false
-			result.Message = "Failed to read response body"
-			return result
+			result.Message = fmt.Sprintf("Assertion failed: %v", err)
+		} else {
+			result.Message += fmt.Sprintf(", %d assertions passed", len(website.Assertions))
 		}
+	}
 
-		if !strings.Contains(string(body), website.CheckContent) {
+	// Compare with the previous or pinned content, skipping error pages
+	if result.IsUp && website.DetectChanges {
+		if err := c.detectChange(&result, website, body); err != nil {
+			result.Error = err
 			result.IsUp = false
-			result.Message = fmt.Sprintf("Content check failed: '%s' not found", website.CheckContent)
+			result.Message = "Change detection failed"
+		} else if result.ContentChanged {
+			result.Message += ", content changed"
 		}
 	}
 
@@ -0,0 +1,48 @@
+package monitor
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// maxResponseBody caps how much of an HTTP response body is read for content
+// checks, assertions and change detection. Anything beyond it is ignored.
+const maxResponseBody = 10 * 1024 * 1024
+
+// needsBody reports whether any check inspects the response body
+func needsBody(website Website) bool {
+	return website.CheckContent != "" || website.MustNotContain != "" ||
+		website.CheckContentRegex != nil || website.MustNotContainRegex != nil ||
+		len(website.Assertions) > 0 || website.DetectChanges
+}
+
+// checkContent applies the literal and regex content checks to a response body
+func checkContent(body []byte, website Website) error {
+	if website.CheckContent != "" && !bytes.Contains(body, []byte(website.CheckContent)) {
+		return fmt.Errorf("'%s' not found", website.CheckContent)
+	}
+
+	if website.MustNotContain != "" && bytes.Contains(body, []byte(website.MustNotContain)) {
+		return fmt.Errorf("'%s' found", website.MustNotContain)
+	}
+
+	if website.CheckContentRegex != nil && !website.CheckContentRegex.Match(body) {
+		return fmt.Errorf("no match for /%s/", website.CheckContentRegex)
+	}
+
+	if website.MustNotContainRegex != nil {
+		if match := website.MustNotContainRegex.Find(body); match != nil {
+			return fmt.Errorf("/%s/ matched '%s'", website.MustNotContainRegex, truncate(string(match), 100))
+		}
+	}
+
+	return nil
+}
+
+// truncate shortens s to at most n bytes for use in messages
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
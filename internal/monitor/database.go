@@ -0,0 +1,202 @@
+package monitor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+// sqlDrivers maps check types to database/sql driver names
+var sqlDrivers = map[string]string{
+	"postgres": "postgres",
+	"mysql":    "mysql",
+}
+
+// defaultQuery is run by SQL checks that don't set their own
+const defaultQuery = "SELECT 1"
+
+// checkSQL connects to a PostgreSQL or MySQL database and runs the
+// website's query. When an expected result is set, the first column of the
+// first row must match it.
+func (c *Checker) checkSQL(ctx context.Context, website Website) CheckResult {
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	dsn, err := website.DSN.Value()
+	if err != nil {
+		result.Error = fmt.Errorf("dsn: %w", err)
+		result.IsUp = false
+		result.Message = "Failed to read DSN"
+		result.Timestamp = time.Now()
+		return result
+	}
+
+	db, err := sql.Open(sqlDrivers[website.Type], dsn)
+	if err != nil {
+		result.Error = fmt.Errorf("invalid dsn: %w", err)
+		result.IsUp = false
+		result.Message = "Failed to open database"
+		result.Timestamp = time.Now()
+		return result
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	query := website.Query
+	if query == "" {
+		query = defaultQuery
+	}
+
+	start := time.Now()
+	value, err := queryFirstColumn(ctx, db, query)
+	result.ResponseTime = time.Since(start)
+	result.Timestamp = time.Now()
+
+	if err != nil {
+		result.Error = fmt.Errorf("query failed: %w", err)
+		result.IsUp = false
+		result.Message = "Database query failed"
+		return result
+	}
+
+	if website.ExpectedResult != "" && strings.TrimSpace(value) != website.ExpectedResult {
+		result.IsUp = false
+		result.Message = fmt.Sprintf("Query returned %q (expected %q)", value, website.ExpectedResult)
+		return result
+	}
+
+	result.IsUp = true
+	result.Message = fmt.Sprintf("Query returned %q", value)
+	return result
+}
+
+// queryFirstColumn runs a query and returns the first column of its first
+// row as text, whatever the number of columns. NULL is returned as "".
+func queryFirstColumn(ctx context.Context, db *sql.DB, query string) (string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	if len(columns) == 0 {
+		return "", fmt.Errorf("query returned no columns")
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+		return "", sql.ErrNoRows
+	}
+
+	values := make([]sql.RawBytes, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return "", err
+	}
+	// RawBytes is only valid until the next call on rows
+	value := string(values[0])
+	return value, rows.Close()
+}
+
+// checkRedis sends PING to a Redis server and, when an expected role is set,
+// compares it with the role from INFO replication
+func (c *Checker) checkRedis(ctx context.Context, website Website) CheckResult {
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	dsn, err := website.DSN.Value()
+	if err != nil {
+		result.Error = fmt.Errorf("dsn: %w", err)
+		result.IsUp = false
+		result.Message = "Failed to read DSN"
+		result.Timestamp = time.Now()
+		return result
+	}
+
+	options, err := redis.ParseURL(dsn)
+	if err != nil {
+		result.Error = fmt.Errorf("invalid dsn: %w", err)
+		result.IsUp = false
+		result.Message = "Failed to parse Redis URL"
+		result.Timestamp = time.Now()
+		return result
+	}
+	options.MaxRetries = -1 // The worker pool handles retries
+	options.PoolSize = 1
+	client := redis.NewClient(options)
+	defer client.Close()
+
+	start := time.Now()
+	err = client.Ping(ctx).Err()
+	result.ResponseTime = time.Since(start)
+	result.Timestamp = time.Now()
+
+	if err != nil {
+		result.Error = fmt.Errorf("ping failed: %w", err)
+		result.IsUp = false
+		result.Message = "Redis PING failed"
+		return result
+	}
+
+	if website.ExpectedRole == "" {
+		result.IsUp = true
+		result.Message = "PONG"
+		return result
+	}
+
+	info, err := client.Info(ctx, "replication").Result()
+	if err != nil {
+		result.Error = fmt.Errorf("info failed: %w", err)
+		result.IsUp = false
+		result.Message = "Redis INFO replication failed"
+		return result
+	}
+
+	role := redisRole(info)
+	if normalizeRole(role) != normalizeRole(website.ExpectedRole) {
+		result.IsUp = false
+		result.Message = fmt.Sprintf("Role %s (expected %s)", role, website.ExpectedRole)
+		return result
+	}
+
+	result.IsUp = true
+	result.Message = fmt.Sprintf("PONG, role %s", role)
+	return result
+}
+
+// redisRole returns the role field of INFO replication output
+func redisRole(info string) string {
+	for _, line := range strings.Split(info, "\n") {
+		if role, found := strings.CutPrefix(strings.TrimSpace(line), "role:"); found {
+			return role
+		}
+	}
+	return "unknown"
+}
+
+// normalizeRole treats Redis' "slave" and "replica" as the same role
+func normalizeRole(role string) string {
+	role = strings.ToLower(role)
+	if role == "slave" {
+		return "replica"
+	}
+	return role
+}
@@ -0,0 +1,276 @@
+package monitor
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ravikantchauhan246/ospy/internal/secret"
+	_ "modernc.org/sqlite"
+)
+
+// openTestDB returns a SQLite database file with a small users table
+func openTestDB(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, email TEXT);
+		INSERT INTO users (name, email) VALUES ('alice', 'alice@example.com'), ('bob', NULL);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestQueryFirstColumn(t *testing.T) {
+	db, err := sql.Open("sqlite", openTestDB(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+		err   error
+	}{
+		{name: "single column", query: "SELECT 1", want: "1"},
+		{name: "multiple columns", query: "SELECT name, email FROM users ORDER BY id", want: "alice"},
+		{name: "count", query: "SELECT COUNT(*), MAX(id) FROM users", want: "2"},
+		{name: "null", query: "SELECT email FROM users WHERE name = 'bob'", want: ""},
+		{name: "no rows", query: "SELECT name FROM users WHERE id = 99", err: sql.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := queryFirstColumn(context.Background(), db, tt.query)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("value = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := queryFirstColumn(context.Background(), db, "SELECT * FROM missing"); err == nil {
+		t.Error("expected an error for a missing table")
+	}
+}
+
+func TestCheckSQL(t *testing.T) {
+	sqlDrivers["sqlite"] = "sqlite"
+	t.Cleanup(func() { delete(sqlDrivers, "sqlite") })
+	t.Setenv("OSPY_TEST_DSN", openTestDB(t))
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+		up       bool
+		message  string
+	}{
+		{name: "default query", up: true, message: `Query returned "1"`},
+		{name: "expected result", query: "SELECT COUNT(*) FROM users", expected: "2", up: true},
+		{name: "unexpected result", query: "SELECT COUNT(*) FROM users", expected: "3", message: `Query returned "2" (expected "3")`},
+		{name: "no rows", query: "SELECT name FROM users WHERE id = 99", message: "Database query failed"},
+		{name: "bad query", query: "SELECT * FROM missing", message: "Database query failed"},
+	}
+
+	checker := NewChecker(5 * time.Second)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			website := Website{
+				Name:           tt.name,
+				Type:           "sqlite",
+				URL:            "sqlite://test",
+				DSN:            secret.Ref{Env: "OSPY_TEST_DSN"},
+				Query:          tt.query,
+				ExpectedResult: tt.expected,
+			}
+
+			result := checker.checkSQL(context.Background(), website)
+			if result.IsUp != tt.up {
+				t.Errorf("up = %v, want %v (%s)", result.IsUp, tt.up, result.Message)
+			}
+			if tt.message != "" && result.Message != tt.message {
+				t.Errorf("message = %q, want %q", result.Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestCheckSQLMissingDSN(t *testing.T) {
+	website := Website{Name: "db", Type: "postgres", DSN: secret.Ref{Env: "OSPY_TEST_UNSET_DSN"}}
+
+	result := NewChecker(5*time.Second).checkSQL(context.Background(), website)
+	if result.IsUp || result.Message != "Failed to read DSN" {
+		t.Errorf("got up = %v, message %q", result.IsUp, result.Message)
+	}
+}
+
+func TestRedisRole(t *testing.T) {
+	tests := []struct {
+		name string
+		info string
+		want string
+	}{
+		{name: "master", info: "# Replication\r\nrole:master\r\nconnected_slaves:1\r\n", want: "master"},
+		{name: "replica", info: "# Replication\r\nrole:slave\r\nmaster_host:10.0.0.1\r\n", want: "slave"},
+		{name: "missing", info: "# Replication\r\n", want: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redisRole(tt.info); got != tt.want {
+				t.Errorf("redisRole = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeRole(t *testing.T) {
+	tests := map[string]string{
+		"master":  "master",
+		"Master":  "master",
+		"slave":   "replica",
+		"SLAVE":   "replica",
+		"replica": "replica",
+	}
+
+	for role, want := range tests {
+		if got := normalizeRole(role); got != want {
+			t.Errorf("normalizeRole(%q) = %q, want %q", role, got, want)
+		}
+	}
+}
+
+// startStubRedis serves just enough RESP for PING and INFO replication and
+// returns a redis:// URL for it
+func startStubRedis(t *testing.T, role string) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveStubRedis(conn, role)
+		}
+	}()
+
+	return "redis://" + listener.Addr().String()
+}
+
+// serveStubRedis answers commands on one connection until it is closed
+func serveStubRedis(conn net.Conn, role string) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			fmt.Fprint(conn, "+PONG\r\n")
+		case "INFO":
+			info := "# Replication\r\nrole:" + role + "\r\n"
+			fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(info), info)
+		case "CLIENT":
+			fmt.Fprint(conn, "+OK\r\n")
+		default:
+			// HELLO and friends; go-redis falls back to RESP2
+			fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+		}
+	}
+}
+
+// readCommand reads one RESP array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("bad command %q", line)
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+	return args, nil
+}
+
+func TestCheckRedis(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		expected string
+		up       bool
+		message  string
+	}{
+		{name: "ping only", role: "master", up: true, message: "PONG"},
+		{name: "expected master", role: "master", expected: "master", up: true, message: "PONG, role master"},
+		{name: "slave is replica", role: "slave", expected: "replica", up: true, message: "PONG, role slave"},
+		{name: "failed over", role: "slave", expected: "master", message: "Role slave (expected master)"},
+	}
+
+	checker := NewChecker(5 * time.Second)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OSPY_TEST_REDIS", startStubRedis(t, tt.role))
+			website := Website{
+				Name:         tt.name,
+				Type:         "redis",
+				DSN:          secret.Ref{Env: "OSPY_TEST_REDIS"},
+				ExpectedRole: tt.expected,
+			}
+
+			result := checker.checkRedis(context.Background(), website)
+			if result.IsUp != tt.up {
+				t.Errorf("up = %v, want %v (%s)", result.IsUp, tt.up, result.Message)
+			}
+			if result.Message != tt.message {
+				t.Errorf("message = %q, want %q", result.Message, tt.message)
+			}
+		})
+	}
+}
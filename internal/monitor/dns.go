@@ -0,0 +1,151 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// checkDNS resolves the website's name and compares the answers against
+// the expected set and/or pattern
+func (c *Checker) checkDNS(ctx context.Context, website Website) CheckResult {
+	recordType := strings.ToUpper(website.RecordType)
+	if recordType == "" {
+		recordType = "A"
+	}
+	name := strings.TrimPrefix(website.URL, "dns://")
+
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	resolver := newResolver(website.Resolver)
+
+	start := time.Now()
+	answers, err := lookup(ctx, resolver, recordType, name)
+	result.ResponseTime = time.Since(start)
+	result.Timestamp = time.Now()
+
+	if err != nil {
+		result.Error = fmt.Errorf("lookup failed: %w", err)
+		result.IsUp = false
+		result.Message = fmt.Sprintf("DNS %s lookup failed", recordType)
+		return result
+	}
+
+	sort.Strings(answers)
+	got := strings.Join(answers, ", ")
+
+	if len(website.ExpectedAnswers) > 0 {
+		expected := make([]string, len(website.ExpectedAnswers))
+		for i, answer := range website.ExpectedAnswers {
+			expected[i] = normalizeAnswer(recordType, answer)
+		}
+		sort.Strings(expected)
+
+		if strings.Join(expected, ", ") != got {
+			result.IsUp = false
+			result.Message = fmt.Sprintf("DNS %s answers [%s] (expected [%s])", recordType, got, strings.Join(expected, ", "))
+			return result
+		}
+	}
+
+	if website.AnswerPattern != nil {
+		for _, answer := range answers {
+			if !website.AnswerPattern.MatchString(answer) {
+				result.IsUp = false
+				result.Message = fmt.Sprintf("DNS %s answer '%s' does not match '%s'", recordType, answer, website.AnswerPattern)
+				return result
+			}
+		}
+	}
+
+	result.IsUp = true
+	result.Message = fmt.Sprintf("DNS %s answers [%s]", recordType, got)
+	return result
+}
+
+// newResolver returns a resolver that sends all queries to server, or the
+// system resolver when server is empty
+func newResolver(server string) *net.Resolver {
+	if server == "" {
+		return net.DefaultResolver
+	}
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// lookup queries name for the given record type and returns the answers
+// in a normalized text form
+func lookup(ctx context.Context, resolver *net.Resolver, recordType, name string) ([]string, error) {
+	var answers []string
+
+	switch recordType {
+	case "A", "AAAA":
+		network := "ip4"
+		if recordType == "AAAA" {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ip := range ips {
+			answers = append(answers, ip.String())
+		}
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, normalizeAnswer(recordType, cname))
+	case "MX":
+		records, err := resolver.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, mx := range records {
+			answers = append(answers, fmt.Sprintf("%d %s", mx.Pref, normalizeAnswer(recordType, mx.Host)))
+		}
+	case "TXT":
+		records, err := resolver.LookupTXT(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		answers = append(answers, records...)
+	case "NS":
+		records, err := resolver.LookupNS(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range records {
+			answers = append(answers, normalizeAnswer(recordType, ns.Host))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", recordType)
+	}
+
+	return answers, nil
+}
+
+// normalizeAnswer lowercases host names and strips the trailing root dot so
+// configured answers compare equal to resolved ones. TXT data is left as is.
+func normalizeAnswer(recordType, answer string) string {
+	if recordType == "TXT" {
+		return answer
+	}
+	return strings.ToLower(strings.TrimSuffix(answer, "."))
+}
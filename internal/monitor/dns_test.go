@@ -0,0 +1,221 @@
+package monitor
+
+import (
+	"context"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// stubZone answers queries for names in example.test. Names are fully
+// qualified and lowercase.
+var stubZone = map[dnsmessage.Type]map[string][]dnsmessage.ResourceBody{
+	dnsmessage.TypeA: {
+		"www.example.test.": {
+			&dnsmessage.AResource{A: [4]byte{192, 0, 2, 10}},
+			&dnsmessage.AResource{A: [4]byte{192, 0, 2, 11}},
+		},
+	},
+	dnsmessage.TypeCNAME: {
+		"docs.example.test.": {&dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("pages.example.test.")}},
+	},
+	dnsmessage.TypeMX: {
+		"example.test.": {
+			&dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mx1.example.test.")},
+			&dnsmessage.MXResource{Pref: 20, MX: dnsmessage.MustNewName("MX2.example.test.")},
+		},
+	},
+	dnsmessage.TypeTXT: {
+		"example.test.": {&dnsmessage.TXTResource{TXT: []string{"v=spf1 -all"}}},
+	},
+	dnsmessage.TypeNS: {
+		"example.test.": {&dnsmessage.NSResource{NS: dnsmessage.MustNewName("ns1.example.test.")}},
+	},
+}
+
+// startStubDNS serves stubZone over UDP on a local port and returns its address
+func startStubDNS(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if reply, err := stubReply(buf[:n]); err == nil {
+				conn.WriteTo(reply, addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+// stubReply builds the answer to a query from stubZone
+func stubReply(query []byte) ([]byte, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil, err
+	}
+	question, err := parser.Question()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(question.Name.String())
+	records, found := stubZone[question.Type][name]
+	rcode := dnsmessage.RCodeSuccess
+	if !found && !knownName(name) {
+		rcode = dnsmessage.RCodeNameError
+	}
+
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID:            header.ID,
+		Response:      true,
+		Authoritative: true,
+		RCode:         rcode,
+	})
+	builder.EnableCompression()
+	builder.StartQuestions()
+	builder.Question(question)
+	builder.StartAnswers()
+	for _, record := range records {
+		rh := dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 60}
+		switch body := record.(type) {
+		case *dnsmessage.AResource:
+			err = builder.AResource(rh, *body)
+		case *dnsmessage.CNAMEResource:
+			err = builder.CNAMEResource(rh, *body)
+		case *dnsmessage.MXResource:
+			err = builder.MXResource(rh, *body)
+		case *dnsmessage.TXTResource:
+			err = builder.TXTResource(rh, *body)
+		case *dnsmessage.NSResource:
+			err = builder.NSResource(rh, *body)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return builder.Finish()
+}
+
+// knownName reports whether the stub zone has any records for name
+func knownName(name string) bool {
+	for _, names := range stubZone {
+		if _, ok := names[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckDNS(t *testing.T) {
+	resolver := startStubDNS(t)
+
+	tests := []struct {
+		name       string
+		host       string
+		recordType string
+		expected   []string
+		pattern    string
+		up         bool
+		message    string
+	}{
+		{
+			name:     "A answers in any order",
+			host:     "www.example.test.",
+			expected: []string{"192.0.2.11", "192.0.2.10"},
+			up:       true,
+			message:  "DNS A answers [192.0.2.10, 192.0.2.11]",
+		},
+		{
+			name:     "A answer hijacked",
+			host:     "www.example.test.",
+			expected: []string{"192.0.2.10"},
+			message:  "DNS A answers [192.0.2.10, 192.0.2.11] (expected [192.0.2.10])",
+		},
+		{
+			name:    "A answers match pattern",
+			host:    "www.example.test.",
+			pattern: `^192\.0\.2\.`,
+			up:      true,
+		},
+		{
+			name:    "A answer outside pattern",
+			host:    "www.example.test.",
+			pattern: `^192\.0\.2\.10$`,
+			message: "DNS A answer '192.0.2.11' does not match '^192\\.0\\.2\\.10$'",
+		},
+		{
+			name:       "CNAME with trailing dot and case",
+			host:       "docs.example.test.",
+			recordType: "cname",
+			expected:   []string{"Pages.Example.Test."},
+			up:         true,
+		},
+		{
+			name:       "MX",
+			host:       "example.test.",
+			recordType: "MX",
+			expected:   []string{"10 mx1.example.test", "20 mx2.example.test"},
+			up:         true,
+		},
+		{
+			name:       "TXT",
+			host:       "example.test.",
+			recordType: "TXT",
+			pattern:    `^v=spf1 `,
+			up:         true,
+		},
+		{
+			name:       "NS",
+			host:       "example.test.",
+			recordType: "NS",
+			expected:   []string{"ns1.example.test"},
+			up:         true,
+		},
+		{
+			name:    "NXDOMAIN",
+			host:    "missing.example.test.",
+			message: "DNS A lookup failed",
+		},
+	}
+
+	checker := NewChecker(5 * time.Second)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			website := Website{
+				Name:            tt.name,
+				Type:            "dns",
+				URL:             "dns://" + tt.host,
+				RecordType:      tt.recordType,
+				Resolver:        resolver,
+				ExpectedAnswers: tt.expected,
+			}
+			if tt.pattern != "" {
+				website.AnswerPattern = regexp.MustCompile(tt.pattern)
+			}
+
+			result := checker.CheckWebsite(context.Background(), website)
+			if result.IsUp != tt.up {
+				t.Errorf("up = %v, want %v (%s)", result.IsUp, tt.up, result.Message)
+			}
+			if tt.message != "" && result.Message != tt.message {
+				t.Errorf("message = %q, want %q", result.Message, tt.message)
+			}
+		})
+	}
+}
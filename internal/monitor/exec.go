@@ -0,0 +1,205 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Nagios plugin exit codes
+const (
+	execOK       = 0
+	execWarning  = 1
+	execCritical = 2
+	execUnknown  = 3
+)
+
+// Metric is one value of Nagios performance data
+type Metric struct {
+	Label string
+	Value float64
+	Unit  string
+	Warn  string // Threshold ranges are kept as written, e.g. "10:20"
+	Crit  string
+	Min   string
+	Max   string
+}
+
+// checkExec runs a command the way Nagios runs plugins. Exit code 0 is UP,
+// 1 DEGRADED, 2 DOWN and anything else UNKNOWN. The command is killed when
+// the check times out.
+func (c *Checker) checkExec(ctx context.Context, website Website) CheckResult {
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	if len(website.Command) == 0 {
+		result.Error = errors.New("no command configured")
+		result.IsUp = false
+		result.Message = "No command configured"
+		result.Timestamp = time.Now()
+		return result
+	}
+
+	cmd := exec.CommandContext(ctx, website.Command[0], website.Command[1:]...)
+	// Don't wait forever on children that keep the output pipes open
+	cmd.WaitDelay = time.Second
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	result.ResponseTime = time.Since(start)
+	result.Timestamp = time.Now()
+
+	message, perfdata := parsePluginOutput(stdout.String())
+	result.Metrics = parsePerfdata(perfdata)
+	if message == "" {
+		message = firstLine(stderr.String())
+	}
+
+	if ctx.Err() != nil {
+		result.Error = fmt.Errorf("command timed out: %w", ctx.Err())
+		result.IsUp = false
+		result.Message = "Command timed out"
+		return result
+	}
+
+	exitCode := execOK
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			result.Error = fmt.Errorf("failed to run command: %w", err)
+			result.IsUp = false
+			result.Unknown = true
+			result.Message = "Failed to run command"
+			return result
+		}
+		exitCode = exitErr.ExitCode()
+	}
+
+	if message == "" {
+		message = fmt.Sprintf("Exit code %d", exitCode)
+	}
+	result.Message = message
+
+	switch exitCode {
+	case execOK:
+		result.IsUp = true
+	case execWarning:
+		result.IsUp = true
+		result.Degraded = true
+	case execCritical:
+		result.IsUp = false
+	default:
+		result.IsUp = false
+		result.Unknown = true
+	}
+
+	return result
+}
+
+// parsePluginOutput splits plugin output into the status text of the first
+// line and the performance data of all lines. Plugins write
+// "TEXT | perfdata" on the first line, optionally followed by long output
+// whose own "| perfdata" part starts on a later line.
+func parsePluginOutput(output string) (message, perfdata string) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+
+	message, perfdata, _ = strings.Cut(lines[0], "|")
+	message = strings.TrimSpace(message)
+
+	rest := strings.Join(lines[1:], "\n")
+	if _, more, found := strings.Cut(rest, "|"); found {
+		perfdata += " " + more
+	}
+	return message, strings.TrimSpace(perfdata)
+}
+
+// parsePerfdata parses space separated 'label'=value[UOM];[warn];[crit];[min];[max]
+// items. Items that can't be parsed, or whose value is "U", are skipped.
+func parsePerfdata(perfdata string) []Metric {
+	var metrics []Metric
+
+	for _, item := range splitPerfdata(perfdata) {
+		label, data, found := strings.Cut(item, "=")
+		if !found {
+			continue
+		}
+		label = strings.ReplaceAll(strings.Trim(label, "'"), "''", "'")
+
+		fields := strings.Split(data, ";")
+		number, unit := splitUnit(fields[0])
+		value, err := strconv.ParseFloat(number, 64)
+		if label == "" || err != nil {
+			continue
+		}
+
+		metric := Metric{Label: label, Value: value, Unit: unit}
+		thresholds := []*string{&metric.Warn, &metric.Crit, &metric.Min, &metric.Max}
+		for i, field := range fields[1:] {
+			if i < len(thresholds) {
+				*thresholds[i] = field
+			}
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return metrics
+}
+
+// splitPerfdata splits perfdata on whitespace, keeping quoted labels that
+// contain spaces together
+func splitPerfdata(perfdata string) []string {
+	var items []string
+	var current strings.Builder
+	quoted := false
+
+	for _, r := range perfdata {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t' || r == '\n') && !quoted:
+			if current.Len() > 0 {
+				items = append(items, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		items = append(items, current.String())
+	}
+	return items
+}
+
+// splitUnit separates a perfdata value such as "85.5%" into number and unit
+func splitUnit(value string) (number, unit string) {
+	end := strings.IndexFunc(value, func(r rune) bool {
+		return !strings.ContainsRune("0123456789.-+eE", r)
+	})
+	if end == -1 {
+		return value, ""
+	}
+	return value[:end], value[end:]
+}
+
+// firstLine returns the first non-empty line of s
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
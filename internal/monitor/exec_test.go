@@ -0,0 +1,110 @@
+package monitor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePerfdata(t *testing.T) {
+	tests := []struct {
+		name     string
+		perfdata string
+		want     []Metric
+	}{
+		{name: "empty", perfdata: "", want: nil},
+		{
+			name:     "value only",
+			perfdata: "users=3",
+			want:     []Metric{{Label: "users", Value: 3}},
+		},
+		{
+			name:     "unit and thresholds",
+			perfdata: "time=0.052s;1.000;2.000;0.000;10.000",
+			want:     []Metric{{Label: "time", Value: 0.052, Unit: "s", Warn: "1.000", Crit: "2.000", Min: "0.000", Max: "10.000"}},
+		},
+		{
+			name:     "percent with ranges",
+			perfdata: "load=85.5%;10:20;@30:40",
+			want:     []Metric{{Label: "load", Value: 85.5, Unit: "%", Warn: "10:20", Crit: "@30:40"}},
+		},
+		{
+			name:     "empty thresholds",
+			perfdata: "rta=1.2ms;;;0",
+			want:     []Metric{{Label: "rta", Value: 1.2, Unit: "ms", Min: "0"}},
+		},
+		{
+			name:     "byte counter",
+			perfdata: "in=1024KB",
+			want:     []Metric{{Label: "in", Value: 1024, Unit: "KB"}},
+		},
+		{
+			name:     "negative and exponent",
+			perfdata: "offset=-0.5s temp=1.5e2",
+			want:     []Metric{{Label: "offset", Value: -0.5, Unit: "s"}, {Label: "temp", Value: 150}},
+		},
+		{
+			name:     "quoted label with spaces",
+			perfdata: "'disk /var'=42%;80;90 inodes=7",
+			want:     []Metric{{Label: "disk /var", Value: 42, Unit: "%", Warn: "80", Crit: "90"}, {Label: "inodes", Value: 7}},
+		},
+		{
+			name:     "escaped quote in label",
+			perfdata: "'it''s'=1",
+			want:     []Metric{{Label: "it's", Value: 1}},
+		},
+		{
+			name:     "extra whitespace",
+			perfdata: "  a=1 \t b=2\n",
+			want:     []Metric{{Label: "a", Value: 1}, {Label: "b", Value: 2}},
+		},
+		{
+			name:     "unknown value skipped",
+			perfdata: "a=U b=2",
+			want:     []Metric{{Label: "b", Value: 2}},
+		},
+		{
+			name:     "malformed items skipped",
+			perfdata: "novalue =5 c=abc d=4",
+			want:     []Metric{{Label: "d", Value: 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePerfdata(tt.perfdata); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePerfdata(%q) = %+v, want %+v", tt.perfdata, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePluginOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		message  string
+		perfdata []string
+	}{
+		{name: "text only", output: "OK - all good\n", message: "OK - all good"},
+		{name: "with perfdata", output: "OK - 3 users | users=3;5;10\n", message: "OK - 3 users", perfdata: []string{"users=3;5;10"}},
+		{
+			name:     "long output",
+			output:   "DISK OK | /=10%\nsecond line\nthird line | /var=20%\n/home=30%\n",
+			message:  "DISK OK",
+			perfdata: []string{"/=10%", "/var=20%", "/home=30%"},
+		},
+		{name: "long output without perfdata", output: "WARNING\ndetails\n", message: "WARNING"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, perfdata := parsePluginOutput(tt.output)
+			if message != tt.message {
+				t.Errorf("message = %q, want %q", message, tt.message)
+			}
+			if got := splitPerfdata(perfdata); !reflect.DeepEqual(got, tt.perfdata) {
+				t.Errorf("perfdata = %q, want %q", got, tt.perfdata)
+			}
+		})
+	}
+}
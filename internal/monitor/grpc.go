@@ -0,0 +1,96 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// checkGRPC calls grpc.health.v1.Health/Check on a host:port target.
+// SERVING is UP, NOT_SERVING is DOWN and anything else is UNKNOWN. Headers
+// are sent as request metadata.
+func (c *Checker) checkGRPC(ctx context.Context, website Website) CheckResult {
+	target := strings.TrimPrefix(website.URL, "grpc://")
+
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	creds := insecure.NewCredentials()
+	if website.GRPCTLS {
+		config, err := newTLSConfig(transportKeyFor(website))
+		if err != nil {
+			result.Error = err
+			result.IsUp = false
+			result.Message = "Failed to configure TLS"
+			result.Timestamp = time.Now()
+			return result
+		}
+		creds = credentials.NewTLS(config)
+	}
+
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		result.Error = fmt.Errorf("invalid target: %w", err)
+		result.IsUp = false
+		result.Message = "Failed to create gRPC client"
+		result.Timestamp = time.Now()
+		return result
+	}
+	defer conn.Close()
+
+	if len(website.Headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(website.Headers))
+	}
+
+	var p peer.Peer
+	start := time.Now()
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx,
+		&healthpb.HealthCheckRequest{Service: website.GRPCService},
+		grpc.Peer(&p))
+	result.ResponseTime = time.Since(start)
+	result.Timestamp = time.Now()
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		result.setCertificate(tlsInfo.State.PeerCertificates)
+	}
+
+	if err != nil {
+		result.Error = fmt.Errorf("health check failed: %w", err)
+		result.IsUp = false
+		switch status.Code(err) {
+		case codes.Unimplemented:
+			result.Message = "Health service not implemented"
+		case codes.NotFound:
+			result.Message = fmt.Sprintf("Service %q unknown to the health server", website.GRPCService)
+		default:
+			result.Message = fmt.Sprintf("gRPC health check failed: %s", status.Code(err))
+		}
+		return result
+	}
+
+	serving := resp.GetStatus()
+	result.Message = fmt.Sprintf("Health status %s", serving)
+	switch serving {
+	case healthpb.HealthCheckResponse_SERVING:
+		result.IsUp = true
+	case healthpb.HealthCheckResponse_NOT_SERVING:
+		result.IsUp = false
+	default:
+		result.IsUp = false
+		result.Unknown = true
+	}
+
+	return result
+}
@@ -0,0 +1,66 @@
+package monitor
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// HeaderRule checks a response header. With no Equals, Matches or Absent
+// set the header only has to be present.
+type HeaderRule struct {
+	Name    string
+	Equals  string
+	Matches *regexp.Regexp // nil when unset
+	Absent  bool
+}
+
+// checkHeaders returns an error describing the first header rule the
+// response fails
+func checkHeaders(header http.Header, rules []HeaderRule) error {
+	for _, rule := range rules {
+		values := header.Values(rule.Name)
+
+		if rule.Absent {
+			if len(values) > 0 {
+				return fmt.Errorf("header %s present ('%s'), expected absent", rule.Name, strings.Join(values, ", "))
+			}
+			continue
+		}
+
+		if len(values) == 0 {
+			return fmt.Errorf("header %s missing", rule.Name)
+		}
+
+		if rule.Equals != "" && !containsValue(values, rule.Equals) {
+			return fmt.Errorf("header %s is '%s' (expected '%s')", rule.Name, strings.Join(values, ", "), rule.Equals)
+		}
+
+		if rule.Matches != nil && !matchesAny(values, rule.Matches) {
+			return fmt.Errorf("header %s is '%s' (expected to match '%s')", rule.Name, strings.Join(values, ", "), rule.Matches)
+		}
+	}
+
+	return nil
+}
+
+// containsValue reports whether any header value equals want
+func containsValue(values []string, want string) bool {
+	for _, value := range values {
+		if value == want {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether any header value matches pattern
+func matchesAny(values []string, pattern *regexp.Regexp) bool {
+	for _, value := range values {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
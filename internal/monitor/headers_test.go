@@ -0,0 +1,37 @@
+package monitor
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestCheckHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	header.Add("Cache-Control", "no-cache")
+	header.Add("Cache-Control", "max-age=60")
+
+	tests := []struct {
+		name string
+		rule HeaderRule
+		ok   bool
+	}{
+		{"present", HeaderRule{Name: "Content-Type"}, true},
+		{"missing", HeaderRule{Name: "X-Frame-Options"}, false},
+		{"equals", HeaderRule{Name: "Cache-Control", Equals: "max-age=60"}, true},
+		{"not equal", HeaderRule{Name: "Cache-Control", Equals: "private"}, false},
+		{"matches", HeaderRule{Name: "Content-Type", Matches: regexp.MustCompile(`^application/json`)}, true},
+		{"no match", HeaderRule{Name: "Content-Type", Matches: regexp.MustCompile(`^text/`)}, false},
+		{"absent", HeaderRule{Name: "Server", Absent: true}, true},
+		{"not absent", HeaderRule{Name: "Cache-Control", Absent: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHeaders(header, []HeaderRule{tt.rule})
+			if (err == nil) != tt.ok {
+				t.Errorf("checkHeaders() = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package monitor
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// mailProtocol holds the commands a mail check needs from one protocol
+type mailProtocol struct {
+	port     string // Plaintext port, used with or without STARTTLS
+	tlsPort  string // Implicit TLS port
+	greet    func(text *textproto.Conn) (string, error)
+	hello    func(text *textproto.Conn) error // Run again after STARTTLS, may be nil
+	startTLS func(text *textproto.Conn) error
+	login    func(text *textproto.Conn, username, password string) error
+	quit     func(text *textproto.Conn)
+}
+
+// mailProtocols maps mail check types to their protocol
+var mailProtocols = map[string]mailProtocol{
+	"smtp": {
+		port:     "25",
+		tlsPort:  "465",
+		greet:    smtpGreet,
+		hello:    smtpHello,
+		startTLS: smtpStartTLS,
+		login:    smtpLogin,
+		quit:     smtpQuit,
+	},
+	"imap": {
+		port:     "143",
+		tlsPort:  "993",
+		greet:    imapGreet,
+		startTLS: imapStartTLS,
+		login:    imapLogin,
+		quit:     imapQuit,
+	},
+	"pop3": {
+		port:     "110",
+		tlsPort:  "995",
+		greet:    pop3Greet,
+		startTLS: pop3StartTLS,
+		login:    pop3Login,
+		quit:     pop3Quit,
+	},
+}
+
+// checkMail connects to an SMTP, IMAP or POP3 server and reads its greeting.
+// It then optionally upgrades the connection with STARTTLS and logs in.
+// A url scheme ending in "s" (smtps://, imaps://, pop3s://) uses implicit TLS.
+func (c *Checker) checkMail(ctx context.Context, website Website) CheckResult {
+	protocol := mailProtocols[website.Type]
+	address, host, implicitTLS := mailTarget(website.URL, protocol)
+
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	tlsConfig, err := newTLSConfig(transportKeyFor(website))
+	if err != nil {
+		result.Error = err
+		result.IsUp = false
+		result.Message = "Failed to configure TLS"
+		result.Timestamp = time.Now()
+		return result
+	}
+	tlsConfig.ServerName = host
+
+	var password string
+	if website.Auth != nil {
+		if password, err = website.Auth.Password.Value(); err != nil {
+			result.Error = fmt.Errorf("password: %w", err)
+			result.IsUp = false
+			result.Message = "Failed to read password"
+			result.Timestamp = time.Now()
+			return result
+		}
+	}
+
+	// fail records a failed step, timed from the start of the check
+	start := time.Now()
+	fail := func(err error, message string) CheckResult {
+		result.ResponseTime = time.Since(start)
+		result.Timestamp = time.Now()
+		result.Error = err
+		result.IsUp = false
+		result.Message = message
+		return result
+	}
+
+	var conn net.Conn
+	if implicitTLS {
+		dialer := tls.Dialer{Config: tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
+	if err != nil {
+		return fail(fmt.Errorf("connection failed: %w", err), "Connection failed")
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.timeout)
+	}
+	conn.SetDeadline(deadline)
+
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		result.setCertificate(tlsConn.ConnectionState().PeerCertificates)
+	}
+
+	text := textproto.NewConn(conn)
+	banner, err := protocol.greet(text)
+	if err != nil {
+		return fail(fmt.Errorf("greeting: %w", err), "Unexpected greeting")
+	}
+
+	if website.StartTLS {
+		if err := protocol.startTLS(text); err != nil {
+			return fail(fmt.Errorf("starttls: %w", err), "STARTTLS refused")
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return fail(fmt.Errorf("handshake failed: %w", err), "TLS handshake failed")
+		}
+		result.setCertificate(tlsConn.ConnectionState().PeerCertificates)
+
+		text = textproto.NewConn(tlsConn)
+		if protocol.hello != nil {
+			if err := protocol.hello(text); err != nil {
+				return fail(fmt.Errorf("greeting after starttls: %w", err), "Unexpected reply after STARTTLS")
+			}
+		}
+	}
+
+	if website.Auth != nil {
+		if err := protocol.login(text, website.Auth.Username, password); err != nil {
+			return fail(fmt.Errorf("login failed: %w", err), "Authentication failed")
+		}
+	}
+
+	result.ResponseTime = time.Since(start)
+	result.Timestamp = time.Now()
+	protocol.quit(text)
+
+	if website.Expect != "" && !strings.Contains(banner, website.Expect) {
+		result.IsUp = false
+		result.Message = fmt.Sprintf("Banner check failed: '%s' not found in %q", website.Expect, banner)
+		return result
+	}
+
+	var details []string
+	if implicitTLS || website.StartTLS {
+		details = append(details, "TLS")
+	}
+	if website.Auth != nil {
+		details = append(details, "authenticated")
+	}
+
+	result.IsUp = true
+	result.Message = banner
+	if len(details) > 0 {
+		result.Message += " (" + strings.Join(details, ", ") + ")"
+	}
+	return result
+}
+
+// mailTarget returns the address to dial and the TLS server name for a mail
+// url. The url is host[:port] or scheme://host[:port], and the port defaults
+// to the protocol's well-known port.
+func mailTarget(rawURL string, protocol mailProtocol) (address, host string, implicitTLS bool) {
+	target := rawURL
+	if scheme, rest, found := strings.Cut(rawURL, "://"); found {
+		target = rest
+		implicitTLS = strings.HasSuffix(scheme, "s")
+	}
+	target = strings.TrimSuffix(target, "/")
+
+	host, port, err := net.SplitHostPort(target)
+	if err != nil {
+		host = strings.Trim(target, "[]")
+		port = protocol.port
+		if implicitTLS {
+			port = protocol.tlsPort
+		}
+	}
+	return net.JoinHostPort(host, port), host, implicitTLS
+}
+
+// smtpGreet reads the 220 greeting and sends EHLO
+func smtpGreet(text *textproto.Conn) (string, error) {
+	_, banner, err := text.ReadResponse(220)
+	if err != nil {
+		return "", err
+	}
+	return firstLine(banner), smtpHello(text)
+}
+
+// smtpHello sends EHLO
+func smtpHello(text *textproto.Conn) error {
+	return smtpCommand(text, 250, "EHLO localhost")
+}
+
+// smtpCommand sends a command and checks the reply code
+func smtpCommand(text *textproto.Conn, expectCode int, format string, args ...any) error {
+	id, err := text.Cmd(format, args...)
+	if err != nil {
+		return err
+	}
+	text.StartResponse(id)
+	defer text.EndResponse(id)
+	_, _, err = text.ReadResponse(expectCode)
+	return err
+}
+
+func smtpStartTLS(text *textproto.Conn) error {
+	return smtpCommand(text, 220, "STARTTLS")
+}
+
+// smtpLogin authenticates with AUTH PLAIN
+func smtpLogin(text *textproto.Conn, username, password string) error {
+	credentials := base64.StdEncoding.EncodeToString([]byte("\x00" + username + "\x00" + password))
+	return smtpCommand(text, 235, "AUTH PLAIN %s", credentials)
+}
+
+func smtpQuit(text *textproto.Conn) {
+	smtpCommand(text, 221, "QUIT")
+}
+
+// imapGreet reads the untagged OK greeting
+func imapGreet(text *textproto.Conn) (string, error) {
+	line, err := text.ReadLine()
+	if err != nil {
+		return "", err
+	}
+	banner, found := strings.CutPrefix(line, "* OK")
+	if !found {
+		return "", fmt.Errorf("%s", line)
+	}
+	// Drop a leading response code such as [CAPABILITY ...]
+	banner = strings.TrimSpace(banner)
+	if strings.HasPrefix(banner, "[") {
+		if _, text, found := strings.Cut(banner, "] "); found {
+			banner = text
+		}
+	}
+	return banner, nil
+}
+
+// imapCommand sends a tagged command and waits for its tagged OK, skipping
+// untagged responses
+func imapCommand(text *textproto.Conn, format string, args ...any) error {
+	tag := fmt.Sprintf("a%d", text.Next())
+	if err := text.PrintfLine(tag+" "+format, args...); err != nil {
+		return err
+	}
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return err
+		}
+		if status, found := strings.CutPrefix(line, tag+" "); found {
+			if !strings.HasPrefix(status, "OK") {
+				return fmt.Errorf("%s", status)
+			}
+			return nil
+		}
+	}
+}
+
+func imapStartTLS(text *textproto.Conn) error {
+	return imapCommand(text, "STARTTLS")
+}
+
+func imapLogin(text *textproto.Conn, username, password string) error {
+	return imapCommand(text, "LOGIN %s %s", imapQuote(username), imapQuote(password))
+}
+
+func imapQuit(text *textproto.Conn) {
+	imapCommand(text, "LOGOUT")
+}
+
+// imapQuote returns s as an IMAP quoted string
+func imapQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// pop3Greet reads the +OK greeting
+func pop3Greet(text *textproto.Conn) (string, error) {
+	return pop3Reply(text)
+}
+
+// pop3Reply reads a single line reply and fails unless it is +OK
+func pop3Reply(text *textproto.Conn) (string, error) {
+	line, err := text.ReadLine()
+	if err != nil {
+		return "", err
+	}
+	message, found := strings.CutPrefix(line, "+OK")
+	if !found {
+		return "", fmt.Errorf("%s", line)
+	}
+	return strings.TrimSpace(message), nil
+}
+
+// pop3Command sends a command and reads its reply
+func pop3Command(text *textproto.Conn, format string, args ...any) error {
+	if err := text.PrintfLine(format, args...); err != nil {
+		return err
+	}
+	_, err := pop3Reply(text)
+	return err
+}
+
+func pop3StartTLS(text *textproto.Conn) error {
+	return pop3Command(text, "STLS")
+}
+
+func pop3Login(text *textproto.Conn, username, password string) error {
+	if err := pop3Command(text, "USER %s", username); err != nil {
+		return err
+	}
+	return pop3Command(text, "PASS %s", password)
+}
+
+func pop3Quit(text *textproto.Conn) {
+	pop3Command(text, "QUIT")
+}
@@ -3,6 +3,7 @@ package monitor
 import (
 	// "fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/ravikantchauhan246/ospy/internal/storage"
@@ -42,11 +43,21 @@ func (m *Monitor) processResults() {
 	for result := range m.resultsCh {
 		// Log result
 		status := "✅"
-		if !result.IsUp {
-			status = "❌"		}
+		if result.SecurityAlert {
+			status = "🔐"
+		} else if result.Unknown {
+			status = "❔"
+		} else if !result.IsUp {
+			status = "❌"
+		} else if result.Degraded {
+			status = "⚠️"
+		}
 		
 		log.Printf("%s %s (%s) - %s (Time: %v)", 
 			status, result.WebsiteName, result.URL, result.Message, result.ResponseTime)
+		if result.Attempts > 1 {
+			log.Printf("   Attempts: %d", result.Attempts)
+		}
 		
 		if result.Error != nil {
 			log.Printf("   Error: %v", result.Error)
@@ -59,12 +70,65 @@ func (m *Monitor) processResults() {
 			Status:       result.Status,
 			ResponseTime: result.ResponseTime.Microseconds(),
 			IsUp:         result.IsUp,
+			IsDegraded:   result.Degraded,
+			IsUnknown:    result.Unknown,
 			Message:      result.Message,
 			Timestamp:    result.Timestamp,
+			Attempts:     result.Attempts,
 		}
 		
 		if result.Error != nil {
 			logEntry.Error = result.Error.Error()		}
+		for _, hop := range result.RedirectChain {
+			logEntry.RedirectChain = append(logEntry.RedirectChain, storage.RedirectHop{URL: hop.URL, Status: hop.Status})
+		}
+		if !result.CertExpiry.IsZero() {
+			certExpiry := result.CertExpiry
+			logEntry.CertExpiry = &certExpiry
+			logEntry.CertIssuer = result.CertIssuer
+			logEntry.CertSANs = strings.Join(result.CertSANs, ",")
+		}
+		for _, step := range result.Steps {
+			logEntry.Steps = append(logEntry.Steps, storage.StepResult{
+				Name:     step.Name,
+				Status:   step.Status,
+				Duration: step.Duration.Microseconds(),
+				Error:    step.Error,
+			})
+		}
+		logEntry.FailedStep = result.FailedStep
+		for _, metric := range result.Metrics {
+			logEntry.Metrics = append(logEntry.Metrics, storage.Metric{
+				Label: metric.Label,
+				Value: metric.Value,
+				Unit:  metric.Unit,
+				Warn:  metric.Warn,
+				Crit:  metric.Crit,
+				Min:   metric.Min,
+				Max:   metric.Max,
+			})
+		}
+		if result.Handshake > 0 {
+			handshake := result.Handshake.Microseconds()
+			logEntry.HandshakeTime = &handshake
+		}
+		if result.RoundTrip > 0 {
+			roundTrip := result.RoundTrip.Microseconds()
+			logEntry.RoundTripTime = &roundTrip
+		}
+		logEntry.HostKey = result.HostKey
+		logEntry.IsSecurityAlert = result.SecurityAlert
+		logEntry.ContentHash = result.ContentHash
+		logEntry.ContentDiff = result.ContentDiff
+		if t := result.Timing; t != (Timing{}) {
+			logEntry.Timing = &storage.PhaseTiming{
+				DNS:       t.DNS.Microseconds(),
+				Connect:   t.Connect.Microseconds(),
+				TLS:       t.TLS.Microseconds(),
+				FirstByte: t.FirstByte.Microseconds(),
+				Transfer:  t.Transfer.Microseconds(),
+			}
+		}
 
 		if err := m.storage.SaveLog(logEntry); err != nil {
 			log.Printf("Failed to save log: %v", err)
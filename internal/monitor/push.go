@@ -0,0 +1,198 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrUnknownPushToken is returned for pings to a token no website uses
+var ErrUnknownPushToken = errors.New("unknown push token")
+
+// Push ping statuses
+const (
+	PingSuccess = "success"
+	PingFail    = "fail"
+	PingStart   = "start"
+)
+
+// Ping is a heartbeat sent by a job
+type Ping struct {
+	Status   string        // success (default), fail, or start to mark the beginning of a run
+	Message  string        // Optional message reported by the job
+	Duration time.Duration // Optional run duration; measured from the start ping if omitted
+}
+
+// heartbeat tracks the pings of one push website
+type heartbeat struct {
+	website  Website
+	deadline time.Time // Website is DOWN if no ping arrives before this
+	started  time.Time // Time of the last start ping, if the run hasn't finished
+}
+
+// PushMonitor watches websites that report in by pinging ospy instead of
+// being polled. A website goes DOWN when no ping arrives within its period
+// plus grace, or when the job reports a failure.
+type PushMonitor struct {
+	mu      sync.Mutex
+	beats   map[string]*heartbeat
+	results chan CheckResult
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{} // Closed when run returns, nil if never started
+}
+
+// NewPushMonitor creates a push monitor for the given push websites
+func NewPushMonitor(websites []Website) *PushMonitor {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	beats := make(map[string]*heartbeat, len(websites))
+	for _, website := range websites {
+		beats[website.PushToken] = &heartbeat{website: website}
+	}
+
+	return &PushMonitor{
+		beats:   beats,
+		results: make(chan CheckResult, len(websites)+1),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start begins watching for missed pings. Every website gets a full period
+// plus grace from now for its first ping.
+func (p *PushMonitor) Start() {
+	now := time.Now()
+
+	p.mu.Lock()
+	for _, beat := range p.beats {
+		beat.deadline = now.Add(beat.website.Period + beat.website.Grace)
+	}
+	p.mu.Unlock()
+
+	p.done = make(chan struct{})
+	go p.run()
+}
+
+// run reports websites whose deadline has passed, once per period while
+// they stay silent
+func (p *PushMonitor) run() {
+	defer close(p.done)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			var missed []CheckResult
+
+			p.mu.Lock()
+			for _, beat := range p.beats {
+				if now.Before(beat.deadline) {
+					continue
+				}
+				website := beat.website
+				missed = append(missed, CheckResult{
+					WebsiteName: website.Name,
+					URL:         website.URL,
+					Timestamp:   now,
+					IsUp:        false,
+					Message:     fmt.Sprintf("No ping received within %v (period %v + grace %v)", website.Period+website.Grace, website.Period, website.Grace),
+					Attempts:    1,
+				})
+				beat.deadline = now.Add(website.Period)
+			}
+			p.mu.Unlock()
+
+			for _, result := range missed {
+				p.send(result)
+			}
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// Ping records a heartbeat for the website with the given token
+func (p *PushMonitor) Ping(token string, ping Ping) error {
+	now := time.Now()
+
+	p.mu.Lock()
+	beat, ok := p.beats[token]
+	if !ok {
+		p.mu.Unlock()
+		return ErrUnknownPushToken
+	}
+
+	switch ping.Status {
+	case "", PingSuccess, PingFail:
+	case PingStart:
+		beat.started = now
+		p.mu.Unlock()
+		return nil
+	default:
+		p.mu.Unlock()
+		return fmt.Errorf("unknown status %q", ping.Status)
+	}
+
+	if ping.Duration == 0 && !beat.started.IsZero() {
+		ping.Duration = now.Sub(beat.started)
+	}
+	beat.started = time.Time{}
+	beat.deadline = now.Add(beat.website.Period + beat.website.Grace)
+	website := beat.website
+	p.mu.Unlock()
+
+	result := CheckResult{
+		WebsiteName:  website.Name,
+		URL:          website.URL,
+		ResponseTime: ping.Duration,
+		Timestamp:    now,
+		IsUp:         ping.Status != PingFail,
+		Attempts:     1,
+	}
+
+	switch {
+	case ping.Status == PingFail && ping.Message != "":
+		result.Message = fmt.Sprintf("Job reported failure: %s", ping.Message)
+	case ping.Status == PingFail:
+		result.Message = "Job reported failure"
+	case ping.Message != "":
+		result.Message = fmt.Sprintf("Ping received: %s", ping.Message)
+	default:
+		result.Message = "Ping received"
+	}
+
+	// max_response_time and warn_response_time apply to the run duration
+	if ping.Duration > 0 {
+		applyResponseTimeThresholds(&result, website)
+	}
+
+	p.send(result)
+	return nil
+}
+
+// send delivers a result unless the monitor is stopping
+func (p *PushMonitor) send(result CheckResult) {
+	select {
+	case p.results <- result:
+	case <-p.ctx.Done():
+	}
+}
+
+// Results returns the results channel
+func (p *PushMonitor) Results() <-chan CheckResult {
+	return p.results
+}
+
+// Stop stops watching for missed pings and closes the results channel. Ping
+// must not be called once Stop has been.
+func (p *PushMonitor) Stop() {
+	p.cancel()
+	if p.done != nil {
+		<-p.done
+	}
+	close(p.results)
+}
@@ -0,0 +1,84 @@
+package monitor
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// defaultMaxRedirects matches the limit of Go's default HTTP client
+const defaultMaxRedirects = 10
+
+// RedirectHop is one response in a redirect chain
+type RedirectHop struct {
+	URL    string
+	Status int
+}
+
+// redirectPolicy returns a CheckRedirect function that records each
+// redirect response in chain and applies the website's redirect settings
+func redirectPolicy(website Website, chain *[]RedirectHop) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if req.Response != nil {
+			*chain = append(*chain, RedirectHop{
+				URL:    req.Response.Request.URL.String(),
+				Status: req.Response.StatusCode,
+			})
+		}
+
+		if website.DisableRedirects {
+			return http.ErrUseLastResponse
+		}
+
+		maxRedirects := website.MaxRedirects
+		if maxRedirects == 0 {
+			maxRedirects = defaultMaxRedirects
+		}
+		if len(via) > maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		return nil
+	}
+}
+
+// checkRedirects records the final hop of the redirect chain and checks
+// the final URL and Location header against the website's expectations
+func checkRedirects(result *CheckResult, resp *http.Response, website Website, chain []RedirectHop) {
+	finalURL := resp.Request.URL.String()
+
+	if len(chain) > 0 || isRedirect(resp.StatusCode) {
+		// With redirects disabled the final response was already recorded
+		if !website.DisableRedirects || len(chain) == 0 {
+			chain = append(chain, RedirectHop{URL: finalURL, Status: resp.StatusCode})
+		}
+		result.RedirectChain = chain
+	}
+
+	if !result.IsUp {
+		return
+	}
+
+	if website.ExpectedFinalURL != "" && finalURL != website.ExpectedFinalURL {
+		result.IsUp = false
+		result.Message = fmt.Sprintf("Final URL %s (expected %s)", finalURL, website.ExpectedFinalURL)
+		return
+	}
+
+	if website.ExpectedLocation != "" {
+		location := resp.Header.Get("Location")
+		if location != website.ExpectedLocation {
+			result.IsUp = false
+			result.Message = fmt.Sprintf("Location header '%s' (expected '%s')", location, website.ExpectedLocation)
+		}
+	}
+}
+
+// isRedirect reports whether status is a redirect status code
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
@@ -1,67 +1,205 @@
 package monitor
 
 import (
+	"container/heap"
 	"context"
 	"log"
+	"math/rand"
 	"time"
 )
 
-// Scheduler manages periodic website checks
+// maxStartupJitter caps the random delay before a website's first check
+const maxStartupJitter = 10 * time.Second
+
+// Scheduler manages periodic website checks. Each website keeps its own
+// next-run time in a min-heap so sites can be checked at different cadences.
 type Scheduler struct {
 	workerPool *WorkerPool
 	websites   []Website
 	interval   time.Duration
-	ticker     *time.Ticker
+	retries    chan *scheduledCheck
 	ctx        context.Context
 	cancel     context.CancelFunc
 }
 
-// NewScheduler creates a new monitoring scheduler
+// Schedule computes the next run time of a cron-scheduled website
+type Schedule interface {
+	Next(time.Time) time.Time
+}
+
+// scheduledCheck is a website waiting in the scheduler queue
+type scheduledCheck struct {
+	website Website
+	next    time.Time
+	attempt int // Retry attempt, 0 for a regular check
+}
+
+// checkQueue is a min-heap of scheduled checks ordered by next run time
+type checkQueue []*scheduledCheck
+
+func (q checkQueue) Len() int           { return len(q) }
+func (q checkQueue) Less(i, j int) bool { return q[i].next.Before(q[j].next) }
+func (q checkQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *checkQueue) Push(x any) {
+	*q = append(*q, x.(*scheduledCheck))
+}
+
+func (q *checkQueue) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
+
+// NewScheduler creates a new monitoring scheduler. interval is used for
+// websites that don't set their own.
 func NewScheduler(workerPool *WorkerPool, websites []Website, interval time.Duration) *Scheduler {
 	ctx, cancel := context.WithCancel(context.Background())
-	
-	return &Scheduler{
+
+	s := &Scheduler{
 		workerPool: workerPool,
 		websites:   websites,
 		interval:   interval,
+		retries:    make(chan *scheduledCheck),
 		ctx:        ctx,
 		cancel:     cancel,
 	}
+	workerPool.retry = s.retry
+	return s
 }
 
 // Start begins the scheduled monitoring
 func (s *Scheduler) Start() {
-	s.ticker = time.NewTicker(s.interval)
-	
-	// Perform initial check
-	go s.runCheck()
-	
-	// Start periodic checks
-	go func() {
-		for {
-			select {
-			case <-s.ticker.C:
-				go s.runCheck()
-			case <-s.ctx.Done():
-				return
+	now := time.Now()
+	queue := make(checkQueue, 0, len(s.websites))
+
+	// Spread the first checks out so many sites don't fire at once
+	for _, website := range s.websites {
+		if website.Schedule != nil {
+			next := website.Schedule.Next(now)
+			if next.IsZero() {
+				log.Printf("Schedule for %s never fires, skipping", website.Name)
+				continue
 			}
+			queue = append(queue, &scheduledCheck{website: website, next: next})
+			continue
+		}
+
+		window := s.intervalFor(website)
+		if window > maxStartupJitter {
+			window = maxStartupJitter
+		}
+		var jitter time.Duration
+		if window > 0 {
+			jitter = time.Duration(rand.Int63n(int64(window)))
 		}
-	}()
+		queue = append(queue, &scheduledCheck{
+			website: website,
+			next:    now.Add(jitter),
+		})
+	}
+	heap.Init(&queue)
+
+	log.Printf("Scheduling checks for %d websites", len(s.websites))
+
+	go s.run(&queue)
 }
 
-// runCheck submits all websites for checking
-func (s *Scheduler) runCheck() {
-	log.Printf("Starting check of %d websites", len(s.websites))
-	
-	for _, website := range s.websites {
-		s.workerPool.Submit(website)
+// run waits for the next due check, submits every website that is due and
+// reschedules it. Retries of failed checks are added to the same queue.
+func (s *Scheduler) run(queue *checkQueue) {
+	timer := time.NewTimer(0)
+	timer.Stop()
+	defer timer.Stop()
+
+	// wait sets the timer for the earliest check in the queue
+	wait := func() {
+		if queue.Len() > 0 {
+			timer.Reset(time.Until((*queue)[0].next))
+		}
+	}
+	wait()
+
+	for {
+		select {
+		case <-timer.C:
+			now := time.Now()
+			var due []checkJob
+
+			for queue.Len() > 0 && !(*queue)[0].next.After(now) {
+				item := (*queue)[0]
+				if item.attempt > 0 {
+					due = append(due, checkJob{website: item.website, attempt: item.attempt})
+					heap.Pop(queue)
+					continue
+				}
+				due = append(due, checkJob{website: item.website, attempt: 1})
+
+				item.next = s.nextRun(item, now)
+				if item.next.IsZero() {
+					heap.Pop(queue)
+					continue
+				}
+				heap.Fix(queue, 0)
+			}
+
+			go s.submit(due)
+			wait()
+		case item := <-s.retries:
+			heap.Push(queue, item)
+			wait()
+		case <-s.ctx.Done():
+			return
+		}
 	}
 }
 
+// submit hands due checks to the worker pool
+func (s *Scheduler) submit(jobs []checkJob) {
+	for _, job := range jobs {
+		s.workerPool.submit(job)
+	}
+}
+
+// retry queues another attempt at a failed check after delay
+func (s *Scheduler) retry(website Website, attempt int, delay time.Duration) {
+	item := &scheduledCheck{
+		website: website,
+		next:    time.Now().Add(delay),
+		attempt: attempt,
+	}
+	select {
+	case s.retries <- item:
+	case <-s.ctx.Done():
+	}
+}
+
+// nextRun returns when a check that just ran should run again
+func (s *Scheduler) nextRun(item *scheduledCheck, now time.Time) time.Time {
+	if item.website.Schedule != nil {
+		return item.website.Schedule.Next(now)
+	}
+
+	// Keep the cadence, but don't try to catch up on missed runs
+	next := item.next.Add(s.intervalFor(item.website))
+	if next.Before(now) {
+		next = now.Add(s.intervalFor(item.website))
+	}
+	return next
+}
+
+// intervalFor returns the website's check interval, or the default
+func (s *Scheduler) intervalFor(website Website) time.Duration {
+	if website.Interval > 0 {
+		return website.Interval
+	}
+	return s.interval
+}
+
 // Stop stops the scheduler
 func (s *Scheduler) Stop() {
-	if s.ticker != nil {
-		s.ticker.Stop()
-	}
 	s.cancel()
-}
\ No newline at end of file
+}
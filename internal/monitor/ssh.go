@@ -0,0 +1,133 @@
+package monitor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// errHostKeyReceived stops the SSH handshake once the host key is known, so
+// no authentication is attempted
+var errHostKeyReceived = errors.New("host key received")
+
+// checkSSH reads an SSH server's version banner and runs key exchange to get
+// its host key. A fingerprint that doesn't match the expected one is reported
+// as a security alert rather than an outage.
+func (c *Checker) checkSSH(ctx context.Context, website Website) CheckResult {
+	address := strings.TrimPrefix(website.URL, "ssh://")
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "22")
+	}
+
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	start := time.Now()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		result.ResponseTime = time.Since(start)
+		result.Timestamp = time.Now()
+		result.Error = fmt.Errorf("connection failed: %w", err)
+		result.IsUp = false
+		result.Message = "SSH connection failed"
+		return result
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.timeout)
+	}
+	conn.SetDeadline(deadline)
+
+	var hostKey ssh.PublicKey
+	config := &ssh.ClientConfig{
+		User: "ospy",
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			hostKey = key
+			return errHostKeyReceived
+		},
+	}
+	if website.HostKeyAlgorithm != "" {
+		config.HostKeyAlgorithms = []string{website.HostKeyAlgorithm}
+	}
+
+	// Keep a copy of what the server sends first to recover its banner
+	recorder := &bannerRecorder{Conn: conn}
+	_, _, _, err = ssh.NewClientConn(recorder, address, config)
+	result.ResponseTime = time.Since(start)
+	result.Timestamp = time.Now()
+
+	banner := recorder.banner()
+	if hostKey == nil {
+		result.Error = fmt.Errorf("handshake failed: %w", err)
+		result.IsUp = false
+		result.Message = "SSH key exchange failed"
+		if banner == "" {
+			result.Message = "No SSH banner received"
+		}
+		return result
+	}
+
+	result.HostKey = ssh.FingerprintSHA256(hostKey)
+
+	if website.HostKeyFingerprint != "" && result.HostKey != website.HostKeyFingerprint {
+		result.IsUp = false
+		result.SecurityAlert = true
+		result.Message = fmt.Sprintf("Host key changed: %s %s (expected %s)",
+			hostKey.Type(), result.HostKey, website.HostKeyFingerprint)
+		return result
+	}
+
+	if website.Expect != "" && !strings.Contains(banner, website.Expect) {
+		result.IsUp = false
+		result.Message = fmt.Sprintf("Banner check failed: '%s' not found in %q", website.Expect, banner)
+		return result
+	}
+
+	result.IsUp = true
+	result.Message = fmt.Sprintf("%s, %s %s", banner, hostKey.Type(), result.HostKey)
+	return result
+}
+
+// maxSSHBanner caps how much of the start of an SSH session is recorded
+const maxSSHBanner = 8 * 1024
+
+// bannerRecorder records the first bytes read from a connection
+type bannerRecorder struct {
+	net.Conn
+	received bytes.Buffer
+}
+
+func (r *bannerRecorder) Read(p []byte) (int, error) {
+	n, err := r.Conn.Read(p)
+	if room := maxSSHBanner - r.received.Len(); room > 0 {
+		r.received.Write(p[:min(n, room)])
+	}
+	return n, err
+}
+
+// banner returns the server's SSH-2.0-... identification line. Servers may
+// send other lines before it.
+func (r *bannerRecorder) banner() string {
+	reader := bufio.NewReader(bytes.NewReader(r.received.Bytes()))
+	for {
+		line, err := reader.ReadString('\n')
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "SSH-") {
+			return line
+		}
+		if err != nil {
+			return ""
+		}
+	}
+}
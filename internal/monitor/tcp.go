@@ -0,0 +1,93 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// maxTCPResponse caps how much of a TCP response is read while looking for the expected string
+const maxTCPResponse = 64 * 1024
+
+// checkTCP connects to a host:port target, optionally sends a payload and
+// waits for an expected substring in the response
+func (c *Checker) checkTCP(ctx context.Context, website Website) CheckResult {
+	address := strings.TrimPrefix(website.URL, "tcp://")
+
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	start := time.Now()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	result.ResponseTime = time.Since(start)
+	result.Timestamp = time.Now()
+
+	if err != nil {
+		result.Error = fmt.Errorf("connection failed: %w", err)
+		result.IsUp = false
+		result.Message = "TCP connection failed"
+		return result
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.timeout)
+	}
+	conn.SetDeadline(deadline)
+
+	if website.Send != "" {
+		if _, err := conn.Write([]byte(website.Send)); err != nil {
+			result.Error = fmt.Errorf("failed to send payload: %w", err)
+			result.IsUp = false
+			result.Message = "Failed to send TCP payload"
+			return result
+		}
+	}
+
+	if website.Expect != "" {
+		found, err := readUntil(conn, website.Expect)
+		result.ResponseTime = time.Since(start)
+		if !found {
+			if err != nil {
+				result.Error = fmt.Errorf("failed to read response: %w", err)
+			}
+			result.IsUp = false
+			result.Message = fmt.Sprintf("Response check failed: '%s' not found", website.Expect)
+			return result
+		}
+		result.IsUp = true
+		result.Message = fmt.Sprintf("Connected and received '%s'", website.Expect)
+		return result
+	}
+
+	result.IsUp = true
+	result.Message = fmt.Sprintf("Connected in %v", result.ResponseTime)
+	return result
+}
+
+// readUntil reads from conn until expect appears, the connection is closed,
+// the deadline passes or maxTCPResponse bytes have been read
+func readUntil(conn net.Conn, expect string) (bool, error) {
+	var received []byte
+	buf := make([]byte, 4096)
+
+	for len(received) < maxTCPResponse {
+		n, err := conn.Read(buf)
+		received = append(received, buf[:n]...)
+		if bytes.Contains(received, []byte(expect)) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
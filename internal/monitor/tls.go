@@ -0,0 +1,70 @@
+package monitor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// checkTLS performs a TLS handshake with a host:port target and reports
+// the leaf certificate's expiry
+func (c *Checker) checkTLS(ctx context.Context, website Website) CheckResult {
+	address := strings.TrimPrefix(website.URL, "tls://")
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "443")
+	}
+
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	config, err := newTLSConfig(transportKeyFor(website))
+	if err != nil {
+		result.Error = err
+		result.IsUp = false
+		result.Message = "Failed to configure TLS"
+		result.Timestamp = time.Now()
+		return result
+	}
+
+	dialer := tls.Dialer{Config: config}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	result.ResponseTime = time.Since(start)
+	result.Timestamp = time.Now()
+
+	if err != nil {
+		result.Error = fmt.Errorf("handshake failed: %w", err)
+		result.IsUp = false
+		result.Message = "TLS handshake failed"
+		return result
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	result.setCertificate(state.PeerCertificates)
+
+	result.IsUp = true
+	result.Message = fmt.Sprintf("Certificate valid until %s", result.CertExpiry.Format("2006-01-02"))
+	return result
+}
+
+// setCertificate records the leaf certificate of a peer chain
+func (r *CheckResult) setCertificate(chain []*x509.Certificate) {
+	if len(chain) == 0 {
+		return
+	}
+
+	leaf := chain[0]
+	r.CertExpiry = leaf.NotAfter
+	r.CertIssuer = leaf.Issuer.CommonName
+	if r.CertIssuer == "" {
+		r.CertIssuer = leaf.Issuer.String()
+	}
+	r.CertSANs = leaf.DNSNames
+}
@@ -0,0 +1,100 @@
+package monitor
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timing breaks an HTTP check down into its phases. The phases don't
+// overlap, so they can be stacked. Connection phases are zero when a
+// kept-alive connection was reused, and add up across redirects.
+type Timing struct {
+	DNS       time.Duration // Resolving the host name
+	Connect   time.Duration // Establishing the TCP connection
+	TLS       time.Duration // TLS handshake
+	FirstByte time.Duration // Waiting for the first response byte after the request was sent
+	Transfer  time.Duration // Reading the response body
+}
+
+// phaseTracer collects phase timings from httptrace callbacks. Callbacks can
+// run on different goroutines (e.g. parallel dials), so access is locked.
+type phaseTracer struct {
+	mu           sync.Mutex
+	timing       Timing
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+}
+
+// withTrace returns a context that records phase timings into t
+func (t *phaseTracer) withTrace(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			t.dnsStart = time.Now()
+			t.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			t.timing.DNS += since(t.dnsStart)
+			t.mu.Unlock()
+		},
+		ConnectStart: func(string, string) {
+			t.mu.Lock()
+			if t.connectStart.IsZero() {
+				t.connectStart = time.Now()
+			}
+			t.mu.Unlock()
+		},
+		ConnectDone: func(string, string, error) {
+			t.mu.Lock()
+			if !t.connectStart.IsZero() {
+				t.timing.Connect += since(t.connectStart)
+				t.connectStart = time.Time{}
+			}
+			t.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			t.tlsStart = time.Now()
+			t.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			t.timing.TLS += since(t.tlsStart)
+			t.mu.Unlock()
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			t.mu.Lock()
+			t.wroteRequest = time.Now()
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			t.firstByte = time.Now()
+			t.timing.FirstByte += since(t.wroteRequest)
+			t.mu.Unlock()
+		},
+	})
+}
+
+// done records the end of the body transfer and returns the timings
+func (t *phaseTracer) done() Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timing.Transfer = since(t.firstByte)
+	return t.timing
+}
+
+// since is time.Since that ignores phases which never started
+func since(start time.Time) time.Duration {
+	if start.IsZero() {
+		return 0
+	}
+	return time.Since(start)
+}
@@ -0,0 +1,223 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ravikantchauhan246/ospy/internal/jsonpath"
+	"github.com/ravikantchauhan246/ospy/internal/statuscode"
+	"github.com/ravikantchauhan246/ospy/internal/stepvar"
+)
+
+// Step is one request of a transaction check
+type Step struct {
+	Name           string
+	Method         string
+	URL            string
+	Headers        map[string]string
+	Body           string
+	ContentType    string
+	ExpectedStatus statuscode.Spec
+	Assertions     []Assertion
+	Extract        []Extract
+}
+
+// Extract saves a value from a step's response for later steps. The value is
+// read from Header or Cookie if one is set, and from JSON otherwise.
+type Extract struct {
+	Name   string
+	JSON   jsonpath.Path
+	Header string
+	Cookie string
+}
+
+// StepResult records how one step of a transaction went
+type StepResult struct {
+	Name     string
+	Status   int
+	Duration time.Duration
+	Error    string // Empty when the step passed
+}
+
+// checkTransaction runs a website's steps in order, stopping at the first
+// one that fails. Steps share a cookie jar so sessions carry over.
+func (c *Checker) checkTransaction(ctx context.Context, website Website) CheckResult {
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	baseClient, err := c.httpClient(website)
+	if err != nil {
+		result.Error = err
+		result.IsUp = false
+		result.Message = "Failed to configure HTTP client"
+		result.Timestamp = time.Now()
+		return result
+	}
+
+	jar, _ := cookiejar.New(nil)
+	client := *baseClient
+	client.Jar = jar
+
+	vars := make(map[string]string)
+	for i, step := range website.Steps {
+		stepResult, err := c.runStep(ctx, &client, website, step, vars)
+		result.Steps = append(result.Steps, stepResult)
+		result.ResponseTime += stepResult.Duration
+		result.Status = stepResult.Status
+
+		if err != nil {
+			result.Error = err
+			result.IsUp = false
+			result.FailedStep = stepResult.Name
+			result.Message = fmt.Sprintf("Step %d (%s) failed: %v", i+1, stepResult.Name, err)
+			result.Timestamp = time.Now()
+			return result
+		}
+	}
+
+	result.IsUp = true
+	result.Message = fmt.Sprintf("All %d steps passed", len(website.Steps))
+	result.Timestamp = time.Now()
+	return result
+}
+
+// runStep performs one step and saves its extracted values into vars
+func (c *Checker) runStep(ctx context.Context, client *http.Client, website Website, step Step, vars map[string]string) (StepResult, error) {
+	stepResult := StepResult{Name: step.Name}
+	if stepResult.Name == "" {
+		stepResult.Name = step.URL
+	}
+
+	fail := func(err error) (StepResult, error) {
+		stepResult.Error = err.Error()
+		return stepResult, err
+	}
+
+	target, err := resolveStepURL(website.URL, stepvar.Expand(step.URL, vars))
+	if err != nil {
+		return fail(err)
+	}
+
+	method := step.Method
+	if method == "" {
+		method = "GET"
+	}
+
+	var body io.Reader
+	if step.Body != "" {
+		// Expand the configured template before adding extracted values, so
+		// a ${VAR} sent back by the server is never replaced with a secret
+		body = strings.NewReader(stepvar.Expand(expandEnv(step.Body), vars))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return fail(fmt.Errorf("failed to create request: %w", err))
+	}
+	if body != nil && step.ContentType != "" {
+		req.Header.Set("Content-Type", step.ContentType)
+	}
+	for key, value := range website.Headers {
+		req.Header.Set(key, value)
+	}
+	for key, value := range step.Headers {
+		req.Header.Set(key, stepvar.Expand(value, vars))
+	}
+	if err := c.authorize(req, website, client); err != nil {
+		return fail(err)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		stepResult.Duration = time.Since(start)
+		return fail(fmt.Errorf("request failed: %w", err))
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	stepResult.Duration = time.Since(start)
+	stepResult.Status = resp.StatusCode
+	if err != nil {
+		return fail(fmt.Errorf("failed to read response body: %w", err))
+	}
+
+	if !step.ExpectedStatus.Match(resp.StatusCode) {
+		return fail(fmt.Errorf("status %d (expected %s)", resp.StatusCode, step.ExpectedStatus))
+	}
+
+	if len(step.Assertions) > 0 {
+		if err := checkAssertions(respBody, step.Assertions); err != nil {
+			return fail(fmt.Errorf("assertion failed: %w", err))
+		}
+	}
+
+	for _, extract := range step.Extract {
+		value, err := extract.from(resp, respBody, client.Jar)
+		if err != nil {
+			return fail(fmt.Errorf("extract %s: %w", extract.Name, err))
+		}
+		vars[extract.Name] = value
+	}
+
+	return stepResult, nil
+}
+
+// from reads the extracted value from a response
+func (e Extract) from(resp *http.Response, body []byte, jar http.CookieJar) (string, error) {
+	switch {
+	case e.Header != "":
+		value := resp.Header.Get(e.Header)
+		if value == "" {
+			return "", fmt.Errorf("header %s not found", e.Header)
+		}
+		return value, nil
+
+	case e.Cookie != "":
+		// Cookies set by this response first, then any the session already had
+		cookies := append(resp.Cookies(), jar.Cookies(resp.Request.URL)...)
+		for _, cookie := range cookies {
+			if cookie.Name == e.Cookie {
+				return cookie.Value, nil
+			}
+		}
+		return "", fmt.Errorf("cookie %s not found", e.Cookie)
+
+	default:
+		var doc any
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return "", fmt.Errorf("response is not valid JSON: %w", err)
+		}
+		value, found := e.JSON.Lookup(doc)
+		if !found {
+			return "", fmt.Errorf("%s not found", e.JSON)
+		}
+		return formatValue(value), nil
+	}
+}
+
+// resolveStepURL resolves a step URL relative to the website URL
+func resolveStepURL(base, target string) (string, error) {
+	ref, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid url: %w", err)
+	}
+	if ref.IsAbs() || base == "" {
+		return ref.String(), nil
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid website url: %w", err)
+	}
+	return baseURL.ResolveReference(ref).String(), nil
+}
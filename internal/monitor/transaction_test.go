@@ -0,0 +1,55 @@
+package monitor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ravikantchauhan246/ospy/internal/jsonpath"
+)
+
+func TestTransactionDoesNotExpandEnvInExtractedValues(t *testing.T) {
+	t.Setenv("API_KEY", "k1")
+	t.Setenv("SUPER_SECRET", "hunter2")
+
+	var sent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"token": "${SUPER_SECRET}"}`)
+		case "/data":
+			body, _ := io.ReadAll(r.Body)
+			sent = string(body)
+		}
+	}))
+	defer server.Close()
+
+	website := Website{
+		Name: "transaction",
+		Type: "transaction",
+		URL:  server.URL,
+		Steps: []Step{
+			{
+				URL:     "/login",
+				Extract: []Extract{{Name: "token", JSON: jsonpath.MustParse("$.token")}},
+			},
+			{
+				Method: "POST",
+				URL:    "/data",
+				Body:   "key=${API_KEY}&token={{token}}",
+			},
+		},
+	}
+
+	result := NewChecker(5*time.Second).CheckWebsite(context.Background(), website)
+	if !result.IsUp {
+		t.Fatalf("check failed: %s", result.Message)
+	}
+	if want := "key=k1&token=${SUPER_SECRET}"; sent != want {
+		t.Errorf("sent body %q, want %q", sent, want)
+	}
+}
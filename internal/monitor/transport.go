@@ -0,0 +1,111 @@
+package monitor
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// transportKey identifies a distinct set of HTTP client settings. Websites
+// with the same settings share a transport and its connection pool.
+type transportKey struct {
+	proxy              string
+	insecureSkipVerify bool
+	caFile             string
+	clientCert         string
+	clientKey          string
+}
+
+// transportKeyFor returns the client settings of a website
+func transportKeyFor(website Website) transportKey {
+	return transportKey{
+		proxy:              website.Proxy,
+		insecureSkipVerify: website.InsecureSkipVerify,
+		caFile:             website.CAFile,
+		clientCert:         website.ClientCert,
+		clientKey:          website.ClientKey,
+	}
+}
+
+// httpClient returns the client to check a website with. Websites without
+// custom settings use the shared default client.
+func (c *Checker) httpClient(website Website) (*http.Client, error) {
+	key := transportKeyFor(website)
+	if key == (transportKey{}) {
+		return c.client, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	transport, ok := c.transports[key]
+	if !ok {
+		var err error
+		transport, err = newTransport(key)
+		if err != nil {
+			return nil, err
+		}
+		c.transports[key] = transport
+	}
+
+	return &http.Client{
+		Timeout:   c.client.Timeout,
+		Transport: transport,
+	}, nil
+}
+
+// newTransport builds a transport for the given settings
+func newTransport(key transportKey) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if key.proxy != "" {
+		proxyURL, err := url.Parse(key.proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(key)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// newTLSConfig builds the TLS settings shared by HTTP and tls checks
+func newTLSConfig(key transportKey) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: key.insecureSkipVerify,
+	}
+
+	if key.caFile != "" {
+		pem, err := os.ReadFile(key.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", key.caFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if key.clientCert != "" {
+		cert, err := tls.LoadX509KeyPair(key.clientCert, key.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,132 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// checkWebSocket performs a WebSocket upgrade handshake. If send is set the
+// message is written after connecting, and if expect is set the check waits
+// for a message containing it before the timeout.
+func (c *Checker) checkWebSocket(ctx context.Context, website Website) CheckResult {
+	result := CheckResult{
+		WebsiteName: website.Name,
+		URL:         website.URL,
+	}
+
+	dialer, err := c.webSocketDialer(website)
+	if err != nil {
+		result.Error = err
+		result.IsUp = false
+		result.Message = "Failed to configure WebSocket client"
+		result.Timestamp = time.Now()
+		return result
+	}
+
+	header := make(http.Header)
+	for key, value := range website.Headers {
+		header.Set(key, value)
+	}
+
+	start := time.Now()
+	conn, resp, err := dialer.DialContext(ctx, website.URL, header)
+	result.Handshake = time.Since(start)
+	result.ResponseTime = result.Handshake
+	result.Timestamp = time.Now()
+
+	if resp != nil {
+		result.Status = resp.StatusCode
+		if resp.TLS != nil {
+			result.setCertificate(resp.TLS.PeerCertificates)
+		}
+	}
+	if err != nil {
+		result.Error = fmt.Errorf("handshake failed: %w", err)
+		result.IsUp = false
+		result.Message = "WebSocket handshake failed"
+		if resp != nil {
+			result.Message = fmt.Sprintf("WebSocket handshake failed with status %d", resp.StatusCode)
+		}
+		return result
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.timeout)
+	}
+	conn.SetWriteDeadline(deadline)
+	conn.SetReadDeadline(deadline)
+
+	sent := time.Now()
+	if website.Send != "" {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(website.Send)); err != nil {
+			result.Error = fmt.Errorf("send failed: %w", err)
+			result.IsUp = false
+			result.Message = "Failed to send message"
+			return result
+		}
+	}
+
+	if website.Expect != "" {
+		// Skip unrelated messages, e.g. a greeting pushed on connect
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				result.RoundTrip = time.Since(sent)
+				result.ResponseTime = result.Handshake + result.RoundTrip
+				result.Timestamp = time.Now()
+				result.Error = fmt.Errorf("read failed: %w", err)
+				result.IsUp = false
+				result.Message = fmt.Sprintf("Expected reply %q not received", website.Expect)
+				return result
+			}
+			if strings.Contains(string(message), website.Expect) {
+				break
+			}
+		}
+		result.RoundTrip = time.Since(sent)
+		result.ResponseTime = result.Handshake + result.RoundTrip
+		result.Timestamp = time.Now()
+	}
+
+	conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), deadline)
+
+	result.IsUp = true
+	result.Message = "WebSocket connected"
+	if website.Expect != "" {
+		result.Message += ", expected reply received"
+	}
+	return result
+}
+
+// webSocketDialer returns a dialer using the website's proxy and TLS settings
+func (c *Checker) webSocketDialer(website Website) (*websocket.Dialer, error) {
+	key := transportKeyFor(website)
+
+	tlsConfig, err := newTLSConfig(key)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: c.timeout,
+		TLSClientConfig:  tlsConfig,
+	}
+	if key.proxy != "" {
+		proxyURL, err := url.Parse(key.proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy: %w", err)
+		}
+		dialer.Proxy = http.ProxyURL(proxyURL)
+	}
+	return dialer, nil
+}
@@ -2,30 +2,123 @@ package monitor
 
 import (
 	"context"
+	"regexp"
 	"sync"
 	"time"
+
+	"github.com/ravikantchauhan246/ospy/internal/secret"
+	"github.com/ravikantchauhan246/ospy/internal/statuscode"
 )
 
 // Website represents a website to monitor
 type Website struct {
-	Name           string
-	URL            string
-	Method         string
-	Headers        map[string]string
-	ExpectedStatus int
-	CheckContent   string
-	Timeout        time.Duration
+	Name            string
+	Type            string
+	URL             string
+	Method          string
+	Headers         map[string]string
+	ExpectedStatus  statuscode.Spec
+	ExpectedHeaders []HeaderRule
+	CheckContent    string
+	Assertions      []Assertion
+	Body            string
+	BodyFile        string
+	ContentType     string
+	Timeout         time.Duration
+	Interval        time.Duration
+	Schedule        Schedule // Cron schedule, replaces Interval when set
+
+	// Content checks besides CheckContent; the regexes are nil when unset
+	MustNotContain      string
+	CheckContentRegex   *regexp.Regexp
+	MustNotContainRegex *regexp.Regexp
+
+	// Content change detection for HTTP checks
+	DetectChanges bool
+	IgnoreRegions []*regexp.Regexp
+	BaselineFile  string
+
+	// Redirect handling for HTTP checks
+	DisableRedirects bool
+	MaxRedirects     int
+	ExpectedFinalURL string
+	ExpectedLocation string
+
+	// Response time thresholds for DEGRADED and DOWN
+	WarnResponseTime time.Duration
+	MaxResponseTime  time.Duration
+
+	// HTTP client settings; CA and client certificate also apply to tls checks
+	Proxy              string
+	InsecureSkipVerify bool
+	CAFile             string
+	ClientCert         string
+	ClientKey          string
+	Auth               *Auth
+
+	// TCP, WebSocket, mail and SSH check settings
+	Send   string
+	Expect string
+
+	// DNS check settings
+	RecordType      string
+	Resolver        string
+	ExpectedAnswers []string
+	AnswerPattern   *regexp.Regexp // nil when unset
+
+	// Transaction check settings
+	Steps []Step
+
+	// gRPC check settings
+	GRPCService string
+	GRPCTLS     bool
+
+	// SSH check settings
+	HostKeyFingerprint string
+	HostKeyAlgorithm   string
+
+	// Mail check settings
+	StartTLS bool
+
+	// Database check settings
+	DSN            secret.Ref
+	Query          string
+	ExpectedResult string
+	ExpectedRole   string
+
+	// Exec check settings
+	Command []string
+
+	// Push check settings
+	PushToken string
+	Period    time.Duration
+	Grace     time.Duration
 }
 
+// maxRetryBackoff caps the delay between retries of a failed check
+const maxRetryBackoff = 10 * time.Minute
+
 // WorkerPool manages concurrent website checking
 type WorkerPool struct {
-	workers int
-	jobs    chan Website
-	results chan CheckResult
-	checker *Checker
-	wg      sync.WaitGroup
-	ctx     context.Context
-	cancel  context.CancelFunc
+	workers      int
+	jobs         chan checkJob
+	results      chan CheckResult
+	checker      *Checker
+	retries      int
+	retryBackoff time.Duration
+	wg           sync.WaitGroup
+	ctx          context.Context
+	cancel       context.CancelFunc
+
+	// retry queues another attempt at a failed check after a delay. It's set
+	// by the scheduler; without one, failures are reported right away.
+	retry func(website Website, attempt int, delay time.Duration)
+}
+
+// checkJob is one attempt at checking a website
+type checkJob struct {
+	website Website
+	attempt int
 }
 
 // NewWorkerPool creates a new worker pool
@@ -34,7 +127,7 @@ func NewWorkerPool(workers int, checker *Checker) *WorkerPool {
 	
 	return &WorkerPool{
 		workers: workers,
-		jobs:    make(chan Website, workers*2),
+		jobs:    make(chan checkJob, workers*2),
 		results: make(chan CheckResult, workers*2),
 		checker: checker,
 		ctx:     ctx,
@@ -42,6 +135,15 @@ func NewWorkerPool(workers int, checker *Checker) *WorkerPool {
 	}
 }
 
+// SetRetries configures how many times a failed check is re-attempted before
+// it is reported, and the initial delay between attempts. The delay doubles
+// after each attempt, up to maxRetryBackoff. Retries are queued by the
+// scheduler, so no worker is kept waiting during the delay.
+func (wp *WorkerPool) SetRetries(retries int, backoff time.Duration) {
+	wp.retries = retries
+	wp.retryBackoff = backoff
+}
+
 // Start initializes and starts all workers
 func (wp *WorkerPool) Start() {
 	for i := 0; i < wp.workers; i++ {
@@ -61,16 +163,17 @@ func (wp *WorkerPool) worker(id int) {
 				return
 			}
 			
-			// Create context with timeout for this specific job
-			ctx := wp.ctx
-			if job.Timeout > 0 {
-				var cancel context.CancelFunc
-				ctx, cancel = context.WithTimeout(wp.ctx, job.Timeout)
-				defer cancel()
+			result := wp.check(job.website)
+			result.Attempts = job.attempt
+
+			// A failed check is re-attempted before it is reported, so a
+			// single dropped packet doesn't report DOWN. Security alerts
+			// are reported at once.
+			if !result.IsUp && !result.SecurityAlert && job.attempt <= wp.retries && wp.retry != nil {
+				wp.retry(job.website, job.attempt+1, wp.retryDelay(job.attempt))
+				continue
 			}
 			
-			result := wp.checker.CheckWebsite(ctx, job)
-			
 			select {
 			case wp.results <- result:
 			case <-wp.ctx.Done():
@@ -83,10 +186,38 @@ func (wp *WorkerPool) worker(id int) {
 	}
 }
 
+// retryDelay returns the delay before retrying a check that failed on the
+// given attempt: the backoff doubled for each earlier retry, up to
+// maxRetryBackoff
+func (wp *WorkerPool) retryDelay(attempt int) time.Duration {
+	delay := wp.retryBackoff
+	for i := 1; i < attempt && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
+
+// check runs a single check attempt with the website's timeout
+func (wp *WorkerPool) check(job Website) CheckResult {
+	ctx := wp.ctx
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(wp.ctx, job.Timeout)
+		defer cancel()
+	}
+
+	return wp.checker.CheckWebsite(ctx, job)
+}
+
 // Submit adds a website to the job queue
 func (wp *WorkerPool) Submit(website Website) {
+	wp.submit(checkJob{website: website, attempt: 1})
+}
+
+// submit adds an attempt at checking a website to the job queue
+func (wp *WorkerPool) submit(job checkJob) {
 	select {
-	case wp.jobs <- website:
+	case wp.jobs <- job:
 	case <-wp.ctx.Done():
 	}
 }
@@ -82,6 +82,99 @@ This is an automated alert from Ospy website monitor.
 	return e.sendEmail(subject, body)
 }
 
+// SendDegradedAlert sends an alert when a website responds slowly
+func (e *EmailNotifier) SendDegradedAlert(websiteName, url, message string) error {
+	if !e.enabled {
+		return nil
+	}
+
+	subject := fmt.Sprintf("⚠️ Website Degraded: %s", websiteName)
+	body := fmt.Sprintf(`
+Website Alert - Service Degraded
+
+Website: %s
+URL: %s
+Status: DEGRADED
+Message: %s
+Time: %s
+
+This is an automated alert from Ospy website monitor.
+`, websiteName, url, message, time.Now().Format("2006-01-02 15:04:05"))
+
+	return e.sendEmail(subject, body)
+}
+
+// SendCertExpiryAlert sends a warning when a website's certificate is about to expire
+func (e *EmailNotifier) SendCertExpiryAlert(websiteName, url string, daysLeft int, expiry time.Time) error {
+	if !e.enabled {
+		return nil
+	}
+
+	subject := fmt.Sprintf("⚠️ Certificate Expiring: %s (%d days)", websiteName, daysLeft)
+	body := fmt.Sprintf(`
+Website Alert - Certificate Expiring
+
+Website: %s
+URL: %s
+Days Remaining: %d
+Expires: %s
+Time: %s
+
+This is an automated alert from Ospy website monitor.
+`, websiteName, url, daysLeft, expiry.Format("2006-01-02 15:04:05"), time.Now().Format("2006-01-02 15:04:05"))
+
+	return e.sendEmail(subject, body)
+}
+
+// SendSecurityAlert sends an alert when a check finds a possible
+// compromise, such as a changed SSH host key
+func (e *EmailNotifier) SendSecurityAlert(websiteName, url, message string) error {
+	if !e.enabled {
+		return nil
+	}
+
+	subject := fmt.Sprintf("🔐 Security Alert: %s", websiteName)
+	body := fmt.Sprintf(`
+Website Alert - Security
+
+Website: %s
+URL: %s
+Status: SECURITY
+Message: %s
+Time: %s
+
+The server's identity no longer matches the configured one. Verify the
+change before connecting to it.
+
+This is an automated alert from Ospy website monitor.
+`, websiteName, url, message, time.Now().Format("2006-01-02 15:04:05"))
+
+	return e.sendEmail(subject, body)
+}
+
+// SendContentChangeAlert sends an alert with a diff when a website's
+// content changes
+func (e *EmailNotifier) SendContentChangeAlert(websiteName, url, diff string) error {
+	if !e.enabled {
+		return nil
+	}
+
+	subject := fmt.Sprintf("📝 Content Changed: %s", websiteName)
+	body := fmt.Sprintf(`
+Website Alert - Content Changed
+
+Website: %s
+URL: %s
+Time: %s
+
+%s
+
+This is an automated alert from Ospy website monitor.
+`, websiteName, url, time.Now().Format("2006-01-02 15:04:05"), diff)
+
+	return e.sendEmail(subject, body)
+}
+
 // SendSummaryReport sends a periodic summary report
 func (e *EmailNotifier) SendSummaryReport(stats []storage.WebsiteStats) error {
 	if !e.enabled {
@@ -98,6 +191,12 @@ func (e *EmailNotifier) SendSummaryReport(stats []storage.WebsiteStats) error {
 		status := "🟢"
 		if stat.LastStatus == "DOWN" {
 			status = "🔴"
+		} else if stat.LastStatus == "DEGRADED" {
+			status = "🟡"
+		} else if stat.LastStatus == "UNKNOWN" {
+			status = "⚪"
+		} else if stat.LastStatus == "SECURITY" {
+			status = "🔐"
 		}
 		
 		body.WriteString(fmt.Sprintf("%s %s\n", status, stat.WebsiteName))
@@ -105,6 +204,7 @@ func (e *EmailNotifier) SendSummaryReport(stats []storage.WebsiteStats) error {
 		body.WriteString(fmt.Sprintf("   Uptime: %.2f%%\n", stat.UptimePercent))
 		body.WriteString(fmt.Sprintf("   Avg Response: %.0fms\n", stat.AvgResponseTime))
 		body.WriteString(fmt.Sprintf("   Total Checks: %d\n", stat.TotalChecks))
+		body.WriteString(fmt.Sprintf("   Degraded Checks: %d\n", stat.DegradedChecks))
 		body.WriteString(fmt.Sprintf("   Last Check: %s\n\n", stat.LastCheck.Format("2006-01-02 15:04:05")))
 	}
 	
@@ -13,22 +13,34 @@ type Notifier interface {
 	IsEnabled() bool
 	SendDownAlert(websiteName, url, message string) error
 	SendUpAlert(websiteName, url string, downtime time.Duration) error
+	SendDegradedAlert(websiteName, url, message string) error
+	SendCertExpiryAlert(websiteName, url string, daysLeft int, expiry time.Time) error
+	SendSecurityAlert(websiteName, url, message string) error
+	SendContentChangeAlert(websiteName, url, diff string) error
 	SendSummaryReport(stats []storage.WebsiteStats) error
 }
 
 // Manager manages all notification services
 type Manager struct {
-	notifiers    []Notifier
-	websiteState map[string]WebsiteState
-	mutex        sync.RWMutex
+	notifiers      []Notifier
+	websiteState   map[string]WebsiteState
+	certThresholds []int
+	mutex          sync.RWMutex
 }
 
 // WebsiteState tracks the state of a website
 type WebsiteState struct {
 	IsUp      bool
+	Degraded  bool
 	LastUp    time.Time
 	LastDown  time.Time
 	LastAlert time.Time
+
+	// CertAlertDays is the lowest expiry threshold already alerted on, 0 if none
+	CertAlertDays int
+
+	// SecurityAlert is set while a security alert is outstanding
+	SecurityAlert bool
 }
 
 // NewManager creates a new notification manager
@@ -39,6 +51,14 @@ func NewManager(notifiers []Notifier) *Manager {
 	}
 }
 
+// SetCertExpiryThresholds sets the days before certificate expiry at which
+// warnings are sent
+func (m *Manager) SetCertExpiryThresholds(days []int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.certThresholds = days
+}
+
 // HandleResult processes a check result and sends notifications if needed
 func (m *Manager) HandleResult(result CheckResult) {
 	m.mutex.Lock()
@@ -49,13 +69,44 @@ func (m *Manager) HandleResult(result CheckResult) {
 	if !exists {
 		currentState = WebsiteState{
 			IsUp:     result.IsUp,
+			Degraded: result.Degraded,
 			LastUp:   time.Now(),
 			LastDown: time.Now(),
 		}
+		m.checkCertificate(result, &currentState)
+		// Security problems and content changes are alerted on even at
+		// startup, as later checks won't report them again
+		if result.SecurityAlert {
+			m.sendSecurityAlert(result.WebsiteName, result.URL, result.Message)
+			currentState.SecurityAlert = true
+			currentState.LastAlert = time.Now()
+		}
+		if result.ContentChanged {
+			m.sendContentChangeAlert(result.WebsiteName, result.URL, result.ContentDiff)
+			currentState.LastAlert = time.Now()
+		}
 		m.websiteState[websiteName] = currentState
 		return // Don't send notifications on first check
 	}
 
+	// Security alerts replace the down alert, and are sent once until resolved
+	if result.SecurityAlert {
+		if !currentState.SecurityAlert {
+			m.sendSecurityAlert(result.WebsiteName, result.URL, result.Message)
+			currentState.SecurityAlert = true
+			currentState.LastAlert = time.Now()
+		}
+		if currentState.IsUp {
+			currentState.IsUp = false
+			currentState.LastDown = time.Now()
+		}
+		currentState.Degraded = false
+		m.checkCertificate(result, &currentState)
+		m.websiteState[websiteName] = currentState
+		return
+	}
+	currentState.SecurityAlert = false
+
 	// Check for state changes
 	if !currentState.IsUp && result.IsUp {
 		// Website came back up
@@ -74,9 +125,54 @@ func (m *Manager) HandleResult(result CheckResult) {
 		currentState.LastAlert = time.Now()
 	}
 
+	if result.ContentChanged {
+		m.sendContentChangeAlert(result.WebsiteName, result.URL, result.ContentDiff)
+		currentState.LastAlert = time.Now()
+	}
+
+	// Check for slow responses while the website is up
+	if result.IsUp && result.Degraded && !currentState.Degraded {
+		m.sendDegradedAlert(result.WebsiteName, result.URL, result.Message)
+		currentState.LastAlert = time.Now()
+	} else if result.IsUp && !result.Degraded && currentState.Degraded {
+		log.Printf("%s response time back to normal", result.WebsiteName)
+	}
+	currentState.Degraded = result.IsUp && result.Degraded
+
+	m.checkCertificate(result, &currentState)
 	m.websiteState[websiteName] = currentState
 }
 
+// checkCertificate sends an expiry warning the first time a certificate
+// crosses each configured threshold
+func (m *Manager) checkCertificate(result CheckResult, state *WebsiteState) {
+	if result.CertExpiry.IsZero() {
+		return
+	}
+
+	daysLeft := int(time.Until(result.CertExpiry).Hours() / 24)
+
+	// Find the lowest threshold the certificate is within
+	crossed := 0
+	for _, days := range m.certThresholds {
+		if daysLeft <= days && (crossed == 0 || days < crossed) {
+			crossed = days
+		}
+	}
+
+	if crossed == 0 {
+		// Not close to expiry (or renewed), re-arm the warnings
+		state.CertAlertDays = 0
+		return
+	}
+	if state.CertAlertDays != 0 && crossed >= state.CertAlertDays {
+		return // Already warned at this threshold
+	}
+
+	state.CertAlertDays = crossed
+	m.sendCertExpiryAlert(result.WebsiteName, result.URL, daysLeft, result.CertExpiry)
+}
+
 // sendDownAlert sends down alerts to all enabled notifiers
 func (m *Manager) sendDownAlert(websiteName, url, message string) {
 	log.Printf("📧 Sending down alert for %s", websiteName)
@@ -103,6 +199,58 @@ func (m *Manager) sendUpAlert(websiteName, url string, downtime time.Duration) {
 	}
 }
 
+// sendDegradedAlert sends degraded alerts to all enabled notifiers
+func (m *Manager) sendDegradedAlert(websiteName, url, message string) {
+	log.Printf("📧 Sending degraded alert for %s", websiteName)
+
+	for _, notifier := range m.notifiers {
+		if notifier.IsEnabled() {
+			if err := notifier.SendDegradedAlert(websiteName, url, message); err != nil {
+				log.Printf("Failed to send degraded alert: %v", err)
+			}
+		}
+	}
+}
+
+// sendCertExpiryAlert sends certificate expiry warnings to all enabled notifiers
+func (m *Manager) sendCertExpiryAlert(websiteName, url string, daysLeft int, expiry time.Time) {
+	log.Printf("📧 Sending certificate expiry alert for %s (%d days left)", websiteName, daysLeft)
+
+	for _, notifier := range m.notifiers {
+		if notifier.IsEnabled() {
+			if err := notifier.SendCertExpiryAlert(websiteName, url, daysLeft, expiry); err != nil {
+				log.Printf("Failed to send certificate expiry alert: %v", err)
+			}
+		}
+	}
+}
+
+// sendSecurityAlert sends security alerts to all enabled notifiers
+func (m *Manager) sendSecurityAlert(websiteName, url, message string) {
+	log.Printf("📧 Sending security alert for %s", websiteName)
+
+	for _, notifier := range m.notifiers {
+		if notifier.IsEnabled() {
+			if err := notifier.SendSecurityAlert(websiteName, url, message); err != nil {
+				log.Printf("Failed to send security alert: %v", err)
+			}
+		}
+	}
+}
+
+// sendContentChangeAlert sends content change alerts to all enabled notifiers
+func (m *Manager) sendContentChangeAlert(websiteName, url, diff string) {
+	log.Printf("📧 Sending content change alert for %s", websiteName)
+
+	for _, notifier := range m.notifiers {
+		if notifier.IsEnabled() {
+			if err := notifier.SendContentChangeAlert(websiteName, url, diff); err != nil {
+				log.Printf("Failed to send content change alert: %v", err)
+			}
+		}
+	}
+}
+
 // SendSummaryReport sends summary reports to all enabled notifiers
 func (m *Manager) SendSummaryReport(stats []storage.WebsiteStats) {
 	log.Printf("📧 Sending summary report for %d websites", len(stats))
@@ -125,5 +273,14 @@ type CheckResult struct {
 	Error        error
 	Timestamp    time.Time
 	IsUp         bool
+	Degraded     bool
 	Message      string
+	CertExpiry   time.Time
+
+	// SecurityAlert marks a possible compromise, e.g. a changed SSH host key
+	SecurityAlert bool
+
+	// ContentChanged is set when the body differs, with a unified diff
+	ContentChanged bool
+	ContentDiff    string
 }
\ No newline at end of file
@@ -83,6 +83,99 @@ func (t *TelegramNotifier) SendUpAlert(websiteName, url string, downtime time.Du
 	return t.sendMessage(text)
 }
 
+// SendDegradedAlert sends an alert when a website responds slowly
+func (t *TelegramNotifier) SendDegradedAlert(websiteName, url, message string) error {
+	if !t.enabled {
+		return nil
+	}
+
+	text := fmt.Sprintf(`⚠️ *Website Degraded*
+
+*Website:* %s
+*URL:* %s
+*Status:* DEGRADED
+*Message:* %s
+*Time:* %s`,
+		escapeMarkdown(websiteName),
+		escapeMarkdown(url),
+		escapeMarkdown(message),
+		time.Now().Format("2006-01-02 15:04:05"))
+
+	return t.sendMessage(text)
+}
+
+// SendCertExpiryAlert sends a warning when a website's certificate is about to expire
+func (t *TelegramNotifier) SendCertExpiryAlert(websiteName, url string, daysLeft int, expiry time.Time) error {
+	if !t.enabled {
+		return nil
+	}
+
+	text := fmt.Sprintf(`⚠️ *Certificate Expiring*
+
+*Website:* %s
+*URL:* %s
+*Days Remaining:* %d
+*Expires:* %s
+*Time:* %s`,
+		escapeMarkdown(websiteName),
+		escapeMarkdown(url),
+		daysLeft,
+		expiry.Format("2006-01-02 15:04:05"),
+		time.Now().Format("2006-01-02 15:04:05"))
+
+	return t.sendMessage(text)
+}
+
+// SendSecurityAlert sends an alert when a check finds a possible
+// compromise, such as a changed SSH host key
+func (t *TelegramNotifier) SendSecurityAlert(websiteName, url, message string) error {
+	if !t.enabled {
+		return nil
+	}
+
+	text := fmt.Sprintf(`🔐 *Security Alert*
+
+*Website:* %s
+*URL:* %s
+*Status:* SECURITY
+*Message:* %s
+*Time:* %s
+
+Verify the change before connecting to the server.`,
+		escapeMarkdown(websiteName),
+		escapeMarkdown(url),
+		escapeMarkdown(message),
+		time.Now().Format("2006-01-02 15:04:05"))
+
+	return t.sendMessage(text)
+}
+
+// maxTelegramDiff keeps content change messages under Telegram's 4096
+// character limit
+const maxTelegramDiff = 3000
+
+// SendContentChangeAlert sends an alert with a diff when a website's
+// content changes
+func (t *TelegramNotifier) SendContentChangeAlert(websiteName, url, diff string) error {
+	if !t.enabled {
+		return nil
+	}
+
+	// Backticks would end the code block
+	diff = strings.ReplaceAll(diff, "`", "'")
+	if len(diff) > maxTelegramDiff {
+		diff = diff[:maxTelegramDiff] + "\n..."
+	}
+
+	text := fmt.Sprintf("📝 *Content Changed*\n\n*Website:* %s\n*URL:* %s\n*Time:* %s\n\n```\n%s\n```",
+		escapeMarkdown(websiteName),
+		escapeMarkdown(url),
+		time.Now().Format("2006-01-02 15:04:05"),
+		diff)
+
+	return t.sendMessage(text)
+}
+
 // SendSummaryReport sends a periodic summary report
 func (t *TelegramNotifier) SendSummaryReport(stats []storage.WebsiteStats) error {
 	if !t.enabled {
@@ -96,6 +189,12 @@ func (t *TelegramNotifier) SendSummaryReport(stats []storage.WebsiteStats) error
 		status := "🟢"
 		if stat.LastStatus == "DOWN" {
 			status = "🔴"
+		} else if stat.LastStatus == "DEGRADED" {
+			status = "🟡"
+		} else if stat.LastStatus == "UNKNOWN" {
+			status = "⚪"
+		} else if stat.LastStatus == "SECURITY" {
+			status = "🔐"
 		}
 		
 		text.WriteString(fmt.Sprintf("%s *%s*\n", status, escapeMarkdown(stat.WebsiteName)))
@@ -0,0 +1,49 @@
+package secret
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Ref points at a secret stored outside the config file, either in an
+// environment variable or in a file. Only the reference is ever written
+// back to the config, never the value.
+type Ref struct {
+	Env  string `yaml:"env,omitempty"`
+	File string `yaml:"file,omitempty"`
+}
+
+// IsZero reports whether the reference is unset
+func (r Ref) IsZero() bool {
+	return r.Env == "" && r.File == ""
+}
+
+// Validate checks that exactly one source is set
+func (r Ref) Validate() error {
+	switch {
+	case r.Env == "" && r.File == "":
+		return fmt.Errorf("env or file is required")
+	case r.Env != "" && r.File != "":
+		return fmt.Errorf("env and file are mutually exclusive")
+	}
+	return nil
+}
+
+// Value reads the secret. It is read on every call so rotated secrets are
+// picked up without a restart. Trailing newlines in files are ignored.
+func (r Ref) Value() (string, error) {
+	if r.File != "" {
+		data, err := os.ReadFile(r.File)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	value, ok := os.LookupEnv(r.Env)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", r.Env)
+	}
+	return value, nil
+}
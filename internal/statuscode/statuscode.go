@@ -0,0 +1,216 @@
+package statuscode
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Range is an inclusive range of HTTP status codes
+type Range struct {
+	Min int
+	Max int
+}
+
+// Spec is a set of accepted HTTP status codes. In YAML and JSON it is
+// written as a single code (200), a class ("2xx"), a range ("200-399") or
+// a list of those ([200, 204, "3xx"]).
+type Spec []Range
+
+// Single returns a spec accepting exactly one status code
+func Single(code int) Spec {
+	return Spec{{Min: code, Max: code}}
+}
+
+// Parse parses one or more status code rules
+func Parse(rules ...string) (Spec, error) {
+	var spec Spec
+	for _, rule := range rules {
+		r, err := parseRange(rule)
+		if err != nil {
+			return nil, err
+		}
+		spec = append(spec, r)
+	}
+	return spec, nil
+}
+
+// parseRange parses a single rule: "200", "2xx" or "200-399"
+func parseRange(rule string) (Range, error) {
+	rule = strings.TrimSpace(rule)
+
+	var r Range
+	switch {
+	case len(rule) == 3 && strings.EqualFold(rule[1:], "xx"):
+		class, err := strconv.Atoi(rule[:1])
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid status class %q", rule)
+		}
+		r = Range{Min: class * 100, Max: class*100 + 99}
+	case strings.Contains(rule, "-"):
+		parts := strings.SplitN(rule, "-", 2)
+		low, err1 := strconv.Atoi(strings.TrimSpace(parts[0]))
+		high, err2 := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err1 != nil || err2 != nil {
+			return Range{}, fmt.Errorf("invalid status range %q", rule)
+		}
+		r = Range{Min: low, Max: high}
+	default:
+		code, err := strconv.Atoi(rule)
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid status code %q", rule)
+		}
+		r = Range{Min: code, Max: code}
+	}
+
+	if r.Min < 100 || r.Max > 599 || r.Min > r.Max {
+		return Range{}, fmt.Errorf("invalid status %q: codes must be between 100 and 599", rule)
+	}
+	return r, nil
+}
+
+// Match reports whether code is accepted. An empty spec accepts only 200.
+func (s Spec) Match(code int) bool {
+	if len(s) == 0 {
+		return code == 200
+	}
+	for _, r := range s {
+		if code >= r.Min && code <= r.Max {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the rules in their config form, e.g. "200, 204" or "2xx"
+func (s Spec) String() string {
+	if len(s) == 0 {
+		return "200"
+	}
+	rules := make([]string, len(s))
+	for i, r := range s {
+		rules[i] = r.String()
+	}
+	return strings.Join(rules, ", ")
+}
+
+// String returns the range in its config form
+func (r Range) String() string {
+	switch {
+	case r.Min == r.Max:
+		return strconv.Itoa(r.Min)
+	case r.Min%100 == 0 && r.Max == r.Min+99:
+		return fmt.Sprintf("%dxx", r.Min/100)
+	default:
+		return fmt.Sprintf("%d-%d", r.Min, r.Max)
+	}
+}
+
+// value returns the range as an int for single codes, otherwise a string
+func (r Range) value() any {
+	if r.Min == r.Max {
+		return r.Min
+	}
+	return r.String()
+}
+
+// values returns the spec in the shape it is written in config files
+func (s Spec) values() any {
+	if len(s) == 1 {
+		return s[0].value()
+	}
+	values := make([]any, len(s))
+	for i, r := range s {
+		values[i] = r.value()
+	}
+	return values
+}
+
+// UnmarshalYAML accepts a single rule or a list of rules
+func (s *Spec) UnmarshalYAML(node *yaml.Node) error {
+	var rules []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		// Null or zero keeps the old "use the default" meaning
+		if node.Tag == "!!null" || node.Value == "0" {
+			*s = nil
+			return nil
+		}
+		rules = []string{node.Value}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			rules = append(rules, item.Value)
+		}
+	default:
+		return fmt.Errorf("line %d: expected_status must be a code, range or list", node.Line)
+	}
+
+	spec, err := Parse(rules...)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", node.Line, err)
+	}
+	*s = spec
+	return nil
+}
+
+// MarshalYAML writes single codes as plain integers for backwards compatibility
+func (s Spec) MarshalYAML() (any, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	return s.values(), nil
+}
+
+// UnmarshalJSON accepts a number, a string or a list of those
+func (s *Spec) UnmarshalJSON(data []byte) error {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var items []any
+	switch v := raw.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []any:
+		items = v
+	default:
+		items = []any{v}
+	}
+
+	rules := make([]string, len(items))
+	for i, item := range items {
+		switch v := item.(type) {
+		case float64:
+			// A zero code keeps the old "use the default" meaning
+			if v == 0 && len(items) == 1 {
+				*s = nil
+				return nil
+			}
+			rules[i] = strconv.Itoa(int(v))
+		case string:
+			rules[i] = v
+		default:
+			return fmt.Errorf("invalid expected_status %v", item)
+		}
+	}
+
+	spec, err := Parse(rules...)
+	if err != nil {
+		return err
+	}
+	*s = spec
+	return nil
+}
+
+// MarshalJSON writes single codes as plain numbers for backwards compatibility
+func (s Spec) MarshalJSON() ([]byte, error) {
+	if len(s) == 0 {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(s.values())
+}
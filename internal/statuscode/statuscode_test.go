@@ -0,0 +1,196 @@
+package statuscode
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []string
+		want    Spec
+		wantErr bool
+	}{
+		{name: "single", rules: []string{"200"}, want: Spec{{200, 200}}},
+		{name: "class", rules: []string{"3xx"}, want: Spec{{300, 399}}},
+		{name: "upper class", rules: []string{"2XX"}, want: Spec{{200, 299}}},
+		{name: "range", rules: []string{"200-399"}, want: Spec{{200, 399}}},
+		{name: "range with spaces", rules: []string{" 200 - 204 "}, want: Spec{{200, 204}}},
+		{name: "mixed", rules: []string{"200", "204", "3xx"}, want: Spec{{200, 200}, {204, 204}, {300, 399}}},
+		{name: "none", rules: nil, want: nil},
+		{name: "not a number", rules: []string{"ok"}, wantErr: true},
+		{name: "bad class", rules: []string{"xxx"}, wantErr: true},
+		{name: "class too high", rules: []string{"6xx"}, wantErr: true},
+		{name: "code too low", rules: []string{"99"}, wantErr: true},
+		{name: "reversed range", rules: []string{"399-200"}, wantErr: true},
+		{name: "half range", rules: []string{"200-"}, wantErr: true},
+		{name: "one bad rule", rules: []string{"200", "abc"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.rules...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    Spec
+		code    int
+		matches bool
+	}{
+		{name: "default accepts 200", spec: nil, code: 200, matches: true},
+		{name: "default rejects 204", spec: nil, code: 204},
+		{name: "single", spec: Single(204), code: 204, matches: true},
+		{name: "single rejects others", spec: Single(204), code: 200},
+		{name: "class lower bound", spec: Spec{{300, 399}}, code: 300, matches: true},
+		{name: "class upper bound", spec: Spec{{300, 399}}, code: 399, matches: true},
+		{name: "outside class", spec: Spec{{300, 399}}, code: 400},
+		{name: "mixed list", spec: Spec{{200, 200}, {300, 399}}, code: 301, matches: true},
+		{name: "mixed list miss", spec: Spec{{200, 200}, {300, 399}}, code: 204},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.Match(tt.code); got != tt.matches {
+				t.Errorf("Match(%d) = %v, want %v", tt.code, got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		spec Spec
+		want string
+	}{
+		{spec: nil, want: "200"},
+		{spec: Single(204), want: "204"},
+		{spec: Spec{{200, 299}}, want: "2xx"},
+		{spec: Spec{{200, 399}}, want: "200-399"},
+		{spec: Spec{{200, 200}, {204, 204}, {300, 399}}, want: "200, 204, 3xx"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.spec.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+type statusConfig struct {
+	ExpectedStatus Spec `yaml:"expected_status,omitempty" json:"expected_status,omitempty"`
+}
+
+func TestYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Spec
+		output  string
+		wantErr bool
+	}{
+		{name: "integer", input: "expected_status: 200", want: Spec{{200, 200}}, output: "expected_status: 200\n"},
+		{name: "class", input: `expected_status: "3xx"`, want: Spec{{300, 399}}, output: "expected_status: 3xx\n"},
+		{name: "range", input: "expected_status: 200-399", want: Spec{{200, 399}}, output: "expected_status: 200-399\n"},
+		{
+			name:   "mixed list",
+			input:  `expected_status: [200, 204, "3xx"]`,
+			want:   Spec{{200, 200}, {204, 204}, {300, 399}},
+			output: "expected_status:\n    - 200\n    - 204\n    - 3xx\n",
+		},
+		{name: "zero means default", input: "expected_status: 0", want: nil, output: "{}\n"},
+		{name: "null means default", input: "expected_status: null", want: nil, output: "{}\n"},
+		{name: "invalid code", input: "expected_status: 700", wantErr: true},
+		{name: "mapping", input: "expected_status: {min: 200}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config statusConfig
+			err := yaml.Unmarshal([]byte(tt.input), &config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(config.ExpectedStatus, tt.want) {
+				t.Errorf("unmarshal = %v, want %v", config.ExpectedStatus, tt.want)
+			}
+
+			data, err := yaml.Marshal(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.output {
+				t.Errorf("marshal = %q, want %q", data, tt.output)
+			}
+
+			var again statusConfig
+			if err := yaml.Unmarshal(data, &again); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(again.ExpectedStatus, tt.want) {
+				t.Errorf("round trip = %v, want %v", again.ExpectedStatus, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Spec
+		output  string
+		wantErr bool
+	}{
+		{name: "number", input: `{"expected_status": 204}`, want: Spec{{204, 204}}, output: `{"expected_status":204}`},
+		{name: "class", input: `{"expected_status": "2xx"}`, want: Spec{{200, 299}}, output: `{"expected_status":"2xx"}`},
+		{
+			name:   "mixed list",
+			input:  `{"expected_status": [200, "301-302"]}`,
+			want:   Spec{{200, 200}, {301, 302}},
+			output: `{"expected_status":[200,"301-302"]}`,
+		},
+		{name: "zero means default", input: `{"expected_status": 0}`, want: nil, output: `{}`},
+		{name: "invalid", input: `{"expected_status": true}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config statusConfig
+			err := json.Unmarshal([]byte(tt.input), &config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(config.ExpectedStatus, tt.want) {
+				t.Errorf("unmarshal = %v, want %v", config.ExpectedStatus, tt.want)
+			}
+
+			data, err := json.Marshal(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.output {
+				t.Errorf("marshal = %s, want %s", data, tt.output)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package stepvar
+
+import "regexp"
+
+// pattern matches {{name}} references to values extracted by earlier steps
+var pattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Names returns the names referenced in s, in order
+func Names(s string) []string {
+	var names []string
+	for _, match := range pattern.FindAllStringSubmatch(s, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// Expand replaces {{name}} references with their values. Unknown names are
+// left as they are.
+func Expand(s string, vars map[string]string) string {
+	return pattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if value, ok := vars[pattern.FindStringSubmatch(ref)[1]]; ok {
+			return value
+		}
+		return ref
+	})
+}
@@ -12,9 +12,75 @@ type MonitorLog struct {
 	Status       int       `json:"status"`
 	ResponseTime int64     `json:"response_time"` // microseconds
 	IsUp         bool      `json:"is_up"`
+	IsDegraded   bool      `json:"is_degraded"`
+	IsUnknown    bool      `json:"is_unknown"`
 	Error        string    `json:"error"`
 	Message      string    `json:"message"`
 	Timestamp    time.Time `json:"timestamp"`
+	Attempts     int       `json:"attempts"`
+
+	RedirectChain []RedirectHop `json:"redirect_chain,omitempty"`
+
+	// TLS leaf certificate details, set for HTTPS and tls checks
+	CertExpiry *time.Time `json:"cert_expiry,omitempty"`
+	CertIssuer string     `json:"cert_issuer,omitempty"`
+	CertSANs   string     `json:"cert_sans,omitempty"` // comma separated
+
+	// Phase breakdown, set for HTTP checks
+	Timing *PhaseTiming `json:"timing,omitempty"`
+
+	// Steps of a transaction check and the name of the one that failed
+	Steps      []StepResult `json:"steps,omitempty"`
+	FailedStep string       `json:"failed_step,omitempty"`
+
+	// Performance data reported by exec checks
+	Metrics []Metric `json:"metrics,omitempty"`
+
+	// WebSocket handshake and message round trip, in microseconds
+	HandshakeTime *int64 `json:"handshake_time,omitempty"`
+	RoundTripTime *int64 `json:"round_trip_time,omitempty"`
+
+	// SSH host key fingerprint, and whether it didn't match the expected one
+	HostKey         string `json:"host_key,omitempty"`
+	IsSecurityAlert bool   `json:"is_security_alert"`
+
+	// Hash of the normalized body, and a diff when it changed
+	ContentHash string `json:"content_hash,omitempty"`
+	ContentDiff string `json:"content_diff,omitempty"`
+}
+
+// Metric is one value of Nagios performance data
+type Metric struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit,omitempty"`
+	Warn  string  `json:"warn,omitempty"`
+	Crit  string  `json:"crit,omitempty"`
+	Min   string  `json:"min,omitempty"`
+	Max   string  `json:"max,omitempty"`
+}
+
+// StepResult is the outcome of one step of a transaction check
+type StepResult struct {
+	Name     string `json:"name"`
+	Status   int    `json:"status"`
+	Duration int64  `json:"duration"` // microseconds
+	Error    string `json:"error,omitempty"`
+}
+
+// PhaseTiming is the time spent in each phase of an HTTP check, in microseconds
+type PhaseTiming struct {
+	DNS       int64 `json:"dns"`
+	Connect   int64 `json:"connect"`
+	TLS       int64 `json:"tls"`
+	FirstByte int64 `json:"first_byte"`
+	Transfer  int64 `json:"transfer"`
+}
+
+// RedirectHop is one response in a redirect chain
+type RedirectHop struct {
+	URL    string `json:"url"`
+	Status int    `json:"status"`
 }
 
 // WebsiteStats represents statistics for a website
@@ -23,10 +89,21 @@ type WebsiteStats struct {
 	URL             string    `json:"url"`
 	TotalChecks     int       `json:"total_checks"`
 	SuccessfulChecks int      `json:"successful_checks"`
+	DegradedChecks  int       `json:"degraded_checks"`
 	UptimePercent   float64   `json:"uptime_percent"`
 	AvgResponseTime float64   `json:"avg_response_time"`
 	LastCheck       time.Time `json:"last_check"`
-	LastStatus      string    `json:"last_status"`
+	LastStatus      string    `json:"last_status"` // UP, DEGRADED, DOWN, UNKNOWN or SECURITY
+
+	CertExpiry        *time.Time `json:"cert_expiry,omitempty"`
+	CertDaysRemaining int        `json:"cert_days_remaining"`
+
+	// Average phase breakdown of HTTP checks, in milliseconds
+	AvgDNSTime       float64 `json:"avg_dns_time"`
+	AvgConnectTime   float64 `json:"avg_connect_time"`
+	AvgTLSTime       float64 `json:"avg_tls_time"`
+	AvgFirstByteTime float64 `json:"avg_first_byte_time"`
+	AvgTransferTime  float64 `json:"avg_transfer_time"`
 }
 
 // Storage interface defines storage operations
@@ -35,6 +112,7 @@ type Storage interface {
 	GetLogs(websiteName string, limit int) ([]MonitorLog, error)
 	GetStats(websiteName string, duration time.Duration) (WebsiteStats, error)
 	GetAllStats(duration time.Duration) ([]WebsiteStats, error)
+	GetLastContentHash(websiteName string) (string, error)
 	Cleanup(retentionDays int) error
 	Close() error
 }
\ No newline at end of file
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -49,15 +50,119 @@ func (s *SQLiteStorage) createTables() error {
 	CREATE INDEX IF NOT EXISTS idx_timestamp ON monitor_logs(timestamp);
 	`
 
-	_, err := s.db.Exec(query)
-	return err
+	if _, err := s.db.Exec(query); err != nil {
+		return err
+	}
+
+	return s.migrate()
+}
+
+// logColumns lists columns added to monitor_logs after the initial schema.
+// Databases created by older versions get them through migrate.
+var logColumns = []struct {
+	name string
+	def  string
+}{
+	{"cert_expiry", "DATETIME"},
+	{"cert_issuer", "TEXT"},
+	{"cert_sans", "TEXT"},
+	{"attempts", "INTEGER DEFAULT 1"},
+	{"is_degraded", "BOOLEAN DEFAULT 0"},
+	{"redirect_chain", "TEXT"}, // JSON encoded []RedirectHop
+	{"dns_time", "INTEGER"},
+	{"connect_time", "INTEGER"},
+	{"tls_time", "INTEGER"},
+	{"first_byte_time", "INTEGER"},
+	{"transfer_time", "INTEGER"},
+	{"steps", "TEXT"}, // JSON encoded []StepResult
+	{"failed_step", "TEXT"},
+	{"is_unknown", "BOOLEAN DEFAULT 0"},
+	{"metrics", "TEXT"}, // JSON encoded []Metric
+	{"handshake_time", "INTEGER"},
+	{"round_trip_time", "INTEGER"},
+	{"host_key", "TEXT"},
+	{"is_security_alert", "BOOLEAN DEFAULT 0"},
+	{"content_hash", "TEXT"},
+	{"content_diff", "TEXT"},
+}
+
+// migrate adds any missing columns to monitor_logs
+func (s *SQLiteStorage) migrate() error {
+	rows, err := s.db.Query(`PRAGMA table_info(monitor_logs)`)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	rows.Close()
+
+	for _, col := range logColumns {
+		if existing[col.name] {
+			continue
+		}
+		if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE monitor_logs ADD COLUMN %s %s", col.name, col.def)); err != nil {
+			return fmt.Errorf("failed to add column %s: %w", col.name, err)
+		}
+	}
+
+	return nil
 }
 
 // SaveLog saves a monitoring log entry
 func (s *SQLiteStorage) SaveLog(log MonitorLog) error {
+	var redirectChain sql.NullString
+	if len(log.RedirectChain) > 0 {
+		data, err := json.Marshal(log.RedirectChain)
+		if err != nil {
+			return fmt.Errorf("failed to encode redirect chain: %w", err)
+		}
+		redirectChain = sql.NullString{String: string(data), Valid: true}
+	}
+
+	var steps sql.NullString
+	if len(log.Steps) > 0 {
+		data, err := json.Marshal(log.Steps)
+		if err != nil {
+			return fmt.Errorf("failed to encode steps: %w", err)
+		}
+		steps = sql.NullString{String: string(data), Valid: true}
+	}
+
+	var metrics sql.NullString
+	if len(log.Metrics) > 0 {
+		data, err := json.Marshal(log.Metrics)
+		if err != nil {
+			return fmt.Errorf("failed to encode metrics: %w", err)
+		}
+		metrics = sql.NullString{String: string(data), Valid: true}
+	}
+
+	var dnsTime, connectTime, tlsTime, firstByteTime, transferTime sql.NullInt64
+	if t := log.Timing; t != nil {
+		dnsTime = sql.NullInt64{Int64: t.DNS, Valid: true}
+		connectTime = sql.NullInt64{Int64: t.Connect, Valid: true}
+		tlsTime = sql.NullInt64{Int64: t.TLS, Valid: true}
+		firstByteTime = sql.NullInt64{Int64: t.FirstByte, Valid: true}
+		transferTime = sql.NullInt64{Int64: t.Transfer, Valid: true}
+	}
+
 	query := `
-	INSERT INTO monitor_logs (website_name, url, status, response_time, is_up, error, message, timestamp)
-	VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	INSERT INTO monitor_logs (website_name, url, status, response_time, is_up, error, message, timestamp,
+		attempts, is_degraded, redirect_chain, cert_expiry, cert_issuer, cert_sans,
+		dns_time, connect_time, tls_time, first_byte_time, transfer_time, steps, failed_step,
+		is_unknown, metrics, handshake_time, round_trip_time, host_key, is_security_alert,
+		content_hash, content_diff)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	_, err := s.db.Exec(query,
 		log.WebsiteName,
@@ -67,7 +172,28 @@ func (s *SQLiteStorage) SaveLog(log MonitorLog) error {
 		log.IsUp,
 		log.Error,
 		log.Message,
-		log.Timestamp)
+		log.Timestamp,
+		log.Attempts,
+		log.IsDegraded,
+		redirectChain,
+		log.CertExpiry,
+		nullString(log.CertIssuer),
+		nullString(log.CertSANs),
+		dnsTime,
+		connectTime,
+		tlsTime,
+		firstByteTime,
+		transferTime,
+		steps,
+		nullString(log.FailedStep),
+		log.IsUnknown,
+		metrics,
+		log.HandshakeTime,
+		log.RoundTripTime,
+		nullString(log.HostKey),
+		log.IsSecurityAlert,
+		nullString(log.ContentHash),
+		nullString(log.ContentDiff))
 
 	return err
 }
@@ -75,7 +201,11 @@ func (s *SQLiteStorage) SaveLog(log MonitorLog) error {
 // GetLogs retrieves recent logs for a website
 func (s *SQLiteStorage) GetLogs(websiteName string, limit int) ([]MonitorLog, error) {
 	query := `
-	SELECT id, website_name, url, status, response_time, is_up, error, message, timestamp
+	SELECT id, website_name, url, status, response_time, is_up, error, message, timestamp,
+		attempts, is_degraded, redirect_chain, cert_expiry, cert_issuer, cert_sans,
+		dns_time, connect_time, tls_time, first_byte_time, transfer_time, steps, failed_step,
+		is_unknown, metrics, handshake_time, round_trip_time, host_key, is_security_alert,
+		content_hash, content_diff
 	FROM monitor_logs
 	WHERE website_name = ?
 	ORDER BY timestamp DESC
@@ -90,7 +220,11 @@ func (s *SQLiteStorage) GetLogs(websiteName string, limit int) ([]MonitorLog, er
 	var logs []MonitorLog
 	for rows.Next() {
 		var log MonitorLog
-		var errorStr sql.NullString
+		var errorStr, redirectChain, certIssuer, certSANs, steps, failedStep, metrics, hostKey, contentHash, contentDiff sql.NullString
+		var attempts sql.NullInt64
+		var isDegraded, isUnknown, isSecurityAlert sql.NullBool
+		var certExpiry sql.NullTime
+		var dnsTime, connectTime, tlsTime, firstByteTime, transferTime sql.NullInt64
 		err := rows.Scan(
 			&log.ID,
 			&log.WebsiteName,
@@ -101,6 +235,27 @@ func (s *SQLiteStorage) GetLogs(websiteName string, limit int) ([]MonitorLog, er
 			&errorStr,
 			&log.Message,
 			&log.Timestamp,
+			&attempts,
+			&isDegraded,
+			&redirectChain,
+			&certExpiry,
+			&certIssuer,
+			&certSANs,
+			&dnsTime,
+			&connectTime,
+			&tlsTime,
+			&firstByteTime,
+			&transferTime,
+			&steps,
+			&failedStep,
+			&isUnknown,
+			&metrics,
+			&log.HandshakeTime,
+			&log.RoundTripTime,
+			&hostKey,
+			&isSecurityAlert,
+			&contentHash,
+			&contentDiff,
 		)
 		if err != nil {
 			return nil, err
@@ -109,6 +264,37 @@ func (s *SQLiteStorage) GetLogs(websiteName string, limit int) ([]MonitorLog, er
 		if errorStr.Valid {
 			log.Error = errorStr.String
 		}
+		log.Attempts = int(attempts.Int64)
+		log.IsDegraded = isDegraded.Bool
+		if redirectChain.Valid {
+			json.Unmarshal([]byte(redirectChain.String), &log.RedirectChain)
+		}
+		if certExpiry.Valid {
+			log.CertExpiry = &certExpiry.Time
+		}
+		log.CertIssuer = certIssuer.String
+		log.CertSANs = certSANs.String
+		if dnsTime.Valid {
+			log.Timing = &PhaseTiming{
+				DNS:       dnsTime.Int64,
+				Connect:   connectTime.Int64,
+				TLS:       tlsTime.Int64,
+				FirstByte: firstByteTime.Int64,
+				Transfer:  transferTime.Int64,
+			}
+		}
+		if steps.Valid {
+			json.Unmarshal([]byte(steps.String), &log.Steps)
+		}
+		log.FailedStep = failedStep.String
+		log.IsUnknown = isUnknown.Bool
+		if metrics.Valid {
+			json.Unmarshal([]byte(metrics.String), &log.Metrics)
+		}
+		log.HostKey = hostKey.String
+		log.IsSecurityAlert = isSecurityAlert.Bool
+		log.ContentHash = contentHash.String
+		log.ContentDiff = contentDiff.String
 
 		logs = append(logs, log)
 	}
@@ -124,18 +310,32 @@ func (s *SQLiteStorage) GetStats(websiteName string, duration time.Duration) (We
 	SELECT 
 		COUNT(*) as total_checks,
 		SUM(CASE WHEN is_up = 1 THEN 1 ELSE 0 END) as successful_checks,
+		SUM(CASE WHEN is_degraded = 1 THEN 1 ELSE 0 END) as degraded_checks,
 		AVG(response_time) as avg_response_time,
+		AVG(dns_time) as avg_dns_time,
+		AVG(connect_time) as avg_connect_time,
+		AVG(tls_time) as avg_tls_time,
+		AVG(first_byte_time) as avg_first_byte_time,
+		AVG(transfer_time) as avg_transfer_time,
 		MAX(timestamp) as last_check
 	FROM monitor_logs
 	WHERE website_name = ? AND timestamp >= ?`
 	var stats WebsiteStats
+	var degradedChecks sql.NullInt64
 	var avgResponseTime sql.NullFloat64
+	var avgDNS, avgConnect, avgTLS, avgFirstByte, avgTransfer sql.NullFloat64
 	var lastCheckStr sql.NullString
 
 	err := s.db.QueryRow(query, websiteName, since).Scan(
 		&stats.TotalChecks,
 		&stats.SuccessfulChecks,
+		&degradedChecks,
 		&avgResponseTime,
+		&avgDNS,
+		&avgConnect,
+		&avgTLS,
+		&avgFirstByte,
+		&avgTransfer,
 		&lastCheckStr,
 	)
 
@@ -144,6 +344,7 @@ func (s *SQLiteStorage) GetStats(websiteName string, duration time.Duration) (We
 	}
 
 	stats.WebsiteName = websiteName
+	stats.DegradedChecks = int(degradedChecks.Int64)
 
 	// Get URL from latest record
 	urlQuery := `SELECT url FROM monitor_logs WHERE website_name = ? ORDER BY timestamp DESC LIMIT 1`
@@ -155,6 +356,12 @@ func (s *SQLiteStorage) GetStats(websiteName string, duration time.Duration) (We
 	if avgResponseTime.Valid {
 		stats.AvgResponseTime = float64(avgResponseTime.Float64) / 1000 // Convert to milliseconds
 	}
+	// NULL for non-HTTP checks and logs written before timings were recorded
+	stats.AvgDNSTime = avgDNS.Float64 / 1000
+	stats.AvgConnectTime = avgConnect.Float64 / 1000
+	stats.AvgTLSTime = avgTLS.Float64 / 1000
+	stats.AvgFirstByteTime = avgFirstByte.Float64 / 1000
+	stats.AvgTransferTime = avgTransfer.Float64 / 1000
 	if lastCheckStr.Valid {
 		// Parse the timestamp string - handle Go's time.Time.String() format
 		timeStr := lastCheckStr.String
@@ -187,15 +394,30 @@ func (s *SQLiteStorage) GetStats(websiteName string, duration time.Duration) (We
 		}
 
 		// Get last status
-		statusQuery := `SELECT is_up FROM monitor_logs WHERE website_name = ? ORDER BY timestamp DESC LIMIT 1`
+		statusQuery := `SELECT is_up, is_degraded, is_unknown, is_security_alert FROM monitor_logs WHERE website_name = ? ORDER BY timestamp DESC LIMIT 1`
 		var isUp bool
-		if err := s.db.QueryRow(statusQuery, websiteName).Scan(&isUp); err == nil {
-			if isUp {
+		var isDegraded, isUnknown, isSecurityAlert sql.NullBool
+		if err := s.db.QueryRow(statusQuery, websiteName).Scan(&isUp, &isDegraded, &isUnknown, &isSecurityAlert); err == nil {
+			if !isUp && isSecurityAlert.Bool {
+				stats.LastStatus = "SECURITY"
+			} else if !isUp && isUnknown.Bool {
+				stats.LastStatus = "UNKNOWN"
+			} else if isUp && isDegraded.Bool {
+				stats.LastStatus = "DEGRADED"
+			} else if isUp {
 				stats.LastStatus = "UP"
 			} else {
 				stats.LastStatus = "DOWN"
 			}
 		}
+
+		// Get latest certificate expiry, if the site reports one
+		certQuery := `SELECT cert_expiry FROM monitor_logs WHERE website_name = ? AND cert_expiry IS NOT NULL ORDER BY timestamp DESC LIMIT 1`
+		var certExpiry sql.NullTime
+		if err := s.db.QueryRow(certQuery, websiteName).Scan(&certExpiry); err == nil && certExpiry.Valid {
+			stats.CertExpiry = &certExpiry.Time
+			stats.CertDaysRemaining = int(time.Until(certExpiry.Time).Hours() / 24)
+		}
 	}
 
 	return stats, nil
@@ -231,6 +453,24 @@ func (s *SQLiteStorage) GetAllStats(duration time.Duration) ([]WebsiteStats, err
 	return allStats, nil
 }
 
+// GetLastContentHash returns the content hash of a website's most recent
+// check with change detection, or "" if there is none
+func (s *SQLiteStorage) GetLastContentHash(websiteName string) (string, error) {
+	query := `
+	SELECT content_hash
+	FROM monitor_logs
+	WHERE website_name = ? AND content_hash IS NOT NULL
+	ORDER BY timestamp DESC
+	LIMIT 1`
+
+	var hash string
+	err := s.db.QueryRow(query, websiteName).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return hash, err
+}
+
 // Cleanup removes old log entries
 func (s *SQLiteStorage) Cleanup(retentionDays int) error {
 	cutoff := time.Now().AddDate(0, 0, -retentionDays)
@@ -247,6 +487,11 @@ func (s *SQLiteStorage) Cleanup(retentionDays int) error {
 	return nil
 }
 
+// nullString stores empty strings as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 // Close closes the database connection
 func (s *SQLiteStorage) Close() error {
 	return s.db.Close()
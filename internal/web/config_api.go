@@ -4,18 +4,30 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/ravikantchauhan246/ospy/internal/config"
+	"github.com/ravikantchauhan246/ospy/internal/statuscode"
 )
 
 // Website represents a website configuration for the API
 type Website struct {
 	ID             int               `json:"id"`
 	Name           string            `json:"name"`
+	Type           string            `json:"type"`
 	URL            string            `json:"url"`
 	Method         string            `json:"method"`
-	ExpectedStatus int               `json:"expected_status"`
+	ExpectedStatus statuscode.Spec   `json:"expected_status"`
 	Headers        map[string]string `json:"headers,omitempty"`
+	Body           string            `json:"body,omitempty"`
+	BodyFile       string            `json:"body_file,omitempty"` // Read-only; local paths can't be set remotely
+	ContentType    string            `json:"content_type,omitempty"`
+	Send           string            `json:"send,omitempty"`
+	Expect         string            `json:"expect,omitempty"`
+	Schedule       string            `json:"schedule,omitempty"`
+	Timezone       string            `json:"timezone,omitempty"`
+	NextRun        *time.Time        `json:"next_run,omitempty"`  // Only for cron-scheduled websites
+	AuthType       string            `json:"auth_type,omitempty"` // Read-only; credentials are never returned
 	Enabled        bool              `json:"enabled"`
 }
 
@@ -96,12 +108,29 @@ func (api *ConfigAPI) getWebsites(w http.ResponseWriter, r *http.Request) {
 		websites[i] = Website{
 			ID:             i,
 			Name:           site.Name,
+			Type:           site.Type,
 			URL:            site.URL,
 			Method:         site.Method,
 			ExpectedStatus: site.ExpectedStatus,
 			Headers:        site.Headers,
+			Body:           site.Body,
+			BodyFile:       site.BodyFile,
+			ContentType:    site.ContentType,
+			Send:           site.Send,
+			Expect:         site.Expect,
+			Schedule:       site.Schedule,
+			Timezone:       site.Timezone,
 			Enabled:        true, // All websites in config are enabled by default
 		}
+		if site.Auth != nil {
+			websites[i].AuthType = site.Auth.Type
+		}
+		if site.Schedule != "" {
+			if schedule, err := config.ParseSchedule(site.Schedule, site.Timezone); err == nil {
+				next := schedule.Next(time.Now())
+				websites[i].NextRun = &next
+			}
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -116,30 +145,55 @@ func (api *ConfigAPI) addWebsite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	website.BodyFile = "" // Read-only, like auth_type
+
 	// Validate required fields
 	if website.Name == "" || website.URL == "" {
 		http.Error(w, "Name and URL are required", http.StatusBadRequest)
 		return
 	}
 
+	if website.Schedule != "" {
+		if _, err := config.ParseSchedule(website.Schedule, website.Timezone); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Set defaults
+	if website.Type == "" {
+		website.Type = config.TypeHTTP
+	}
 	if website.Method == "" {
 		website.Method = "GET"
 	}
-	if website.ExpectedStatus == 0 {
-		website.ExpectedStatus = 200
+	if website.Type == config.TypeHTTP && len(website.ExpectedStatus) == 0 {
+		website.ExpectedStatus = statuscode.Single(200)
 	}
 	// Add to config
 	newSite := config.WebsiteConfig{
 		Name:           website.Name,
+		Type:           website.Type,
 		URL:            website.URL,
 		Method:         website.Method,
 		ExpectedStatus: website.ExpectedStatus,
 		Headers:        website.Headers,
+		Body:           website.Body,
+		ContentType:    website.ContentType,
+		Send:           website.Send,
+		Expect:         website.Expect,
+		Schedule:       website.Schedule,
+		Timezone:       website.Timezone,
+	}
+
+	websites := append([]config.WebsiteConfig(nil), api.config.Websites...)
+	websites = append(websites, newSite)
+	if err := api.validate(websites); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
+	api.config.Websites = websites
 
-	api.config.Websites = append(api.config.Websites, newSite)
-	
 	// Save configuration
 	if err := api.saveConfig(); err != nil {
 		http.Error(w, "Failed to save configuration", http.StatusInternalServerError)
@@ -166,12 +220,38 @@ func (api *ConfigAPI) updateWebsite(w http.ResponseWriter, r *http.Request, id i
 		return
 	}
 
-	// Update the website
-	api.config.Websites[id].Name = website.Name
-	api.config.Websites[id].URL = website.URL
-	api.config.Websites[id].Method = website.Method
-	api.config.Websites[id].ExpectedStatus = website.ExpectedStatus
-	api.config.Websites[id].Headers = website.Headers
+	if website.Schedule != "" {
+		if _, err := config.ParseSchedule(website.Schedule, website.Timezone); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	// Keep the current type when it's omitted
+	if website.Type == "" {
+		website.Type = api.config.Websites[id].Type
+	}
+	website.BodyFile = api.config.Websites[id].BodyFile
+
+	// Update a copy of the website, so nothing changes if it's invalid
+	websites := append([]config.WebsiteConfig(nil), api.config.Websites...)
+	websites[id].Name = website.Name
+	websites[id].Type = website.Type
+	websites[id].URL = website.URL
+	websites[id].Method = website.Method
+	websites[id].ExpectedStatus = website.ExpectedStatus
+	websites[id].Headers = website.Headers
+	websites[id].Body = website.Body
+	websites[id].ContentType = website.ContentType
+	websites[id].Send = website.Send
+	websites[id].Expect = website.Expect
+	websites[id].Schedule = website.Schedule
+	websites[id].Timezone = website.Timezone
+
+	if err := api.validate(websites); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	api.config.Websites = websites
 
 	// Save configuration
 	if err := api.saveConfig(); err != nil {
@@ -212,10 +292,12 @@ func (api *ConfigAPI) handleSettings(w http.ResponseWriter, r *http.Request) {
 
 	settings := map[string]interface{}{
 		"monitoring": map[string]interface{}{
-			"interval": api.config.Monitoring.Interval.String(),
-			"timeout":  api.config.Monitoring.Timeout.String(),
-			"retries":  api.config.Monitoring.Retries,
-			"workers":  api.config.Monitoring.Workers,
+			"interval":         api.config.Monitoring.Interval.String(),
+			"timeout":          api.config.Monitoring.Timeout.String(),
+			"retries":          api.config.Monitoring.Retries,
+			"retry_backoff":    api.config.Monitoring.RetryBackoff.String(),
+			"workers":          api.config.Monitoring.Workers,
+			"cert_expiry_days": api.config.Monitoring.CertExpiryDays,
 		},
 		"notifications": map[string]interface{}{
 			"email_enabled":    api.config.Notifications.Email.Enabled,
@@ -227,6 +309,13 @@ func (api *ConfigAPI) handleSettings(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(settings)
 }
 
+// validate checks the configuration as it would be with the given websites
+func (api *ConfigAPI) validate(websites []config.WebsiteConfig) error {
+	updated := *api.config
+	updated.Websites = websites
+	return updated.Validate()
+}
+
 // saveConfig saves the current configuration to file
 func (api *ConfigAPI) saveConfig() error {
 	return config.SaveConfig(api.config, api.configPath)
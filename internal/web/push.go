@@ -0,0 +1,67 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ravikantchauhan246/ospy/internal/monitor"
+)
+
+// SetPushMonitor enables the /api/push/{token} heartbeat endpoint
+func (s *Server) SetPushMonitor(pushMonitor *monitor.PushMonitor) {
+	s.pushMonitor = pushMonitor
+}
+
+// handlePush records a heartbeat from a job. Optional query parameters:
+// status (success, fail or start), msg, and duration (e.g. "95s" or a number
+// of seconds).
+func (s *Server) handlePush(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" && r.Method != "HEAD" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/push/"), "/")
+	query := r.URL.Query()
+
+	ping := monitor.Ping{
+		Status:  query.Get("status"),
+		Message: query.Get("msg"),
+	}
+	if durationStr := query.Get("duration"); durationStr != "" {
+		duration, err := parseRunDuration(durationStr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ping.Duration = duration
+	}
+
+	if err := s.pushMonitor.Ping(token, ping); err != nil {
+		if errors.Is(err, monitor.ErrUnknownPushToken) {
+			http.Error(w, "Unknown push token", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintln(w, "OK")
+}
+
+// parseRunDuration accepts a Go duration ("1m30s") or a number of seconds
+func parseRunDuration(s string) (time.Duration, error) {
+	if duration, err := time.ParseDuration(s); err == nil && duration >= 0 {
+		return duration, nil
+	}
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
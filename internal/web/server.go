@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -8,14 +9,21 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/ravikantchauhan246/ospy/internal/monitor"
 	"github.com/ravikantchauhan246/ospy/internal/storage"
 )
 
 // Server provides a web interface
 type Server struct {
-	storage   storage.Storage
-	port      int
-	configAPI *ConfigAPI
+	storage     storage.Storage
+	port        int
+	configAPI   *ConfigAPI
+	pushMonitor *monitor.PushMonitor
+	server      *http.Server
+
+	// certWarningDays is the remaining validity at which a certificate is
+	// highlighted on the dashboard
+	certWarningDays int
 }
 
 // NewServer creates a new web server
@@ -23,6 +31,18 @@ func NewServer(storage storage.Storage, port int) *Server {
 	return &Server{
 		storage: storage,
 		port:    port,
+		server:  &http.Server{Addr: fmt.Sprintf(":%d", port)},
+
+		certWarningDays: 14,
+	}
+}
+
+// SetCertExpiryThresholds highlights certificates on the dashboard once they
+// are within the largest of the alert thresholds
+func (s *Server) SetCertExpiryThresholds(days []int) {
+	s.certWarningDays = 0
+	for _, d := range days {
+		s.certWarningDays = max(s.certWarningDays, d)
 	}
 }
 
@@ -41,9 +61,19 @@ func (s *Server) Start() error {
 	if s.configAPI != nil {
 		s.setupConfigRoutes(s.configAPI)
 	}
+
+	if s.pushMonitor != nil {
+		http.HandleFunc("/api/push/", s.handlePush)
+	}
 	
 	fmt.Printf("Web dashboard available at: http://localhost:%d\n", s.port)
-	return http.ListenAndServe(fmt.Sprintf(":%d", s.port), nil)
+	return s.server.ListenAndServe()
+}
+
+// Stop stops accepting requests and waits for those in progress to finish.
+// Start returns http.ErrServerClosed once it's called.
+func (s *Server) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
 }
 
 // handleIndex serves the main dashboard page
@@ -68,9 +98,20 @@ func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
         .stat-card h3 { margin-top: 0; color: #2c3e50; }
         .status-up { color: #27ae60; font-weight: bold; }
         .status-down { color: #e74c3c; font-weight: bold; }
+        .status-degraded { color: #f39c12; font-weight: bold; }
+        .status-unknown { color: #7f8c8d; font-weight: bold; }
+        .status-security { color: #8e44ad; font-weight: bold; }
         .metric { display: flex; justify-content: space-between; margin: 10px 0; }
         .metric-label { color: #7f8c8d; }
         .metric-value { font-weight: bold; }
+        .timing-bar { display: flex; height: 10px; border-radius: 5px; overflow: hidden; background: #ecf0f1; }
+        .timing-legend { display: flex; flex-wrap: wrap; gap: 10px; margin-top: 6px; font-size: 12px; color: #7f8c8d; }
+        .timing-legend i { display: inline-block; width: 10px; height: 10px; border-radius: 2px; margin-right: 4px; }
+        .phase-dns { background: #9b59b6; }
+        .phase-connect { background: #3498db; }
+        .phase-tls { background: #1abc9c; }
+        .phase-first-byte { background: #f39c12; }
+        .phase-transfer { background: #95a5a6; }
         .footer { text-align: center; margin-top: 40px; color: #7f8c8d; }
     </style>
     <script>
@@ -108,9 +149,15 @@ func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
             <div class="stat-card">
                 <h3>{{.WebsiteName}}</h3>                <div class="metric">
                     <span class="metric-label">Status:</span>
-                    <span class="metric-value {{if eq .LastStatus "UP"}}status-up{{else}}status-down{{end}}">
+                    <span class="metric-value {{if eq .LastStatus "UP"}}status-up{{else if eq .LastStatus "DEGRADED"}}status-degraded{{else if eq .LastStatus "UNKNOWN"}}status-unknown{{else if eq .LastStatus "SECURITY"}}status-security{{else}}status-down{{end}}">
                         {{if eq .LastStatus "UP"}}
                             <img src="data:image/svg+xml;base64,PHN2ZyB3aWR0aD0iMjAiIGhlaWdodD0iMjAiIHZpZXdCb3g9IjAgMCAyMCAyMCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KPGNpcmNsZSBjeD0iMTAiIGN5PSIxMCIgcj0iMTAiIGZpbGw9IiMyN2FlNjAiLz4KPHN2ZyB3aWR0aD0iMTQiIGhlaWdodD0iMTQiIHZpZXdCb3g9IjAgMCAxNCAxNCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIiB4PSIzIiB5PSIzIj4KPHBhdGggZD0iTTMgN0w2IDEwTDExIDQiIHN0cm9rZT0id2hpdGUiIHN0cm9rZS13aWR0aD0iMiIgc3Ryb2tlLWxpbmVjYXA9InJvdW5kIiBzdHJva2UtbGluZWpvaW49InJvdW5kIi8+Cjwvc3ZnPgo8L3N2Zz4K" alt="UP" style="vertical-align: middle; margin-right: 5px;"> UP
+                        {{else if eq .LastStatus "DEGRADED"}}
+                            &#9888; DEGRADED
+                        {{else if eq .LastStatus "UNKNOWN"}}
+                            ? UNKNOWN
+                        {{else if eq .LastStatus "SECURITY"}}
+                            &#128274; SECURITY
                         {{else}}
                             <img src="data:image/svg+xml;base64,PHN2ZyB3aWR0aD0iMjAiIGhlaWdodD0iMjAiIHZpZXdCb3g9IjAgMCAyMCAyMCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIj4KPGNpcmNsZSBjeD0iMTAiIGN5PSIxMCIgcj0iMTAiIGZpbGw9IiNlNzRjM2MiLz4KPHN2ZyB3aWR0aD0iMTQiIGhlaWdodD0iMTQiIHZpZXdCb3g9IjAgMCAxNCAxNCIgZmlsbD0ibm9uZSIgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIiB4PSIzIiB5PSIzIj4KPHBhdGggZD0iTTEwIDRMNCA0TDQgMTAiIHN0cm9rZT0id2hpdGUiIHN0cm9rZS13aWR0aD0iMiIgc3Ryb2tlLWxpbmVjYXA9InJvdW5kIiBzdHJva2UtbGluZWpvaW49InJvdW5kIi8+CjxwYXRoIGQ9Ik00IDEwTDEwIDQiIHN0cm9rZT0id2hpdGUiIHN0cm9rZS13aWR0aD0iMiIgc3Ryb2tlLWxpbmVjYXA9InJvdW5kIiBzdHJva2UtbGluZWpvaW49InJvdW5kIi8+Cjwvc3ZnPgo8L3N2Zz4K" alt="DOWN" style="vertical-align: middle; margin-right: 5px;"> DOWN
                         {{end}}
@@ -128,10 +175,33 @@ func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
                     <span class="metric-label">Avg Response:</span>
                     <span class="metric-value">{{printf "%.0fms" .AvgResponseTime}}</span>
                 </div>
+                {{with timingPhases .}}
+                <div class="metric">
+                    <span class="metric-label">Breakdown:</span>
+                </div>
+                <div class="timing-bar">
+                    {{range .}}<span class="{{.Class}}" style="width: {{printf "%.1f" .Percent}}%" title="{{.Name}}: {{printf "%.1fms" .Millis}}"></span>{{end}}
+                </div>
+                <div class="timing-legend">
+                    {{range .}}<span><i class="{{.Class}}"></i>{{.Name}} {{printf "%.1fms" .Millis}}</span>{{end}}
+                </div>
+                {{end}}
+                {{if .CertExpiry}}
+                <div class="metric">
+                    <span class="metric-label">Certificate:</span>
+                    <span class="metric-value {{if le .CertDaysRemaining $.CertWarningDays}}status-down{{end}}">{{.CertDaysRemaining}} days left</span>
+                </div>
+                {{end}}
                 <div class="metric">
                     <span class="metric-label">Total Checks:</span>
                     <span class="metric-value">{{.TotalChecks}}</span>
                 </div>
+                {{if .DegradedChecks}}
+                <div class="metric">
+                    <span class="metric-label">Degraded Checks:</span>
+                    <span class="metric-value status-degraded">{{.DegradedChecks}}</span>
+                </div>
+                {{end}}
                 <div class="metric">
                     <span class="metric-label">Last Check:</span>
                     <span class="metric-value">{{.LastCheck.Format "15:04:05"}}</span>
@@ -152,23 +222,58 @@ func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
 </body>
 </html>`
 
-	t, err := template.New("dashboard").Parse(tmpl)
+	t, err := template.New("dashboard").Funcs(template.FuncMap{"timingPhases": timingPhases}).Parse(tmpl)
 	if err != nil {
 		http.Error(w, "Template error", http.StatusInternalServerError)
 		return
 	}
 	data := struct {
-		Stats []storage.WebsiteStats
-		Now   time.Time
+		Stats           []storage.WebsiteStats
+		Now             time.Time
+		CertWarningDays int
 	}{
-		Stats: stats,
-		Now:   time.Now(),
+		Stats:           stats,
+		Now:             time.Now(),
+		CertWarningDays: s.certWarningDays,
 	}
 
 	w.Header().Set("Content-Type", "text/html")
 	t.Execute(w, data)
 }
 
+// timingPhase is one segment of the response time breakdown bar
+type timingPhase struct {
+	Name    string
+	Class   string
+	Millis  float64
+	Percent float64
+}
+
+// timingPhases returns the average phase breakdown of a website's checks,
+// or nil when it has no HTTP timings
+func timingPhases(stats storage.WebsiteStats) []timingPhase {
+	phases := []timingPhase{
+		{Name: "DNS", Class: "phase-dns", Millis: stats.AvgDNSTime},
+		{Name: "Connect", Class: "phase-connect", Millis: stats.AvgConnectTime},
+		{Name: "TLS", Class: "phase-tls", Millis: stats.AvgTLSTime},
+		{Name: "Waiting", Class: "phase-first-byte", Millis: stats.AvgFirstByteTime},
+		{Name: "Transfer", Class: "phase-transfer", Millis: stats.AvgTransferTime},
+	}
+
+	var total float64
+	for _, phase := range phases {
+		total += phase.Millis
+	}
+	if total == 0 {
+		return nil
+	}
+
+	for i := range phases {
+		phases[i].Percent = phases[i].Millis / total * 100
+	}
+	return phases
+}
+
 // handleStats serves statistics as JSON
 func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
 	durationStr := r.URL.Query().Get("duration")